@@ -0,0 +1,371 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// AvailabilityOptions contains options for availability calculation
+type AvailabilityOptions struct {
+	Now         time.Time // End date for incidents without end date (current time by default)
+	MinorWeight float64   // Weight of minor incidents in downtime (0-1, 0 by default)
+}
+
+// AvailabilityReport contains availability info for services and zones
+type AvailabilityReport struct {
+	From     time.Time                               `json:"from"`
+	To       time.Time                               `json:"to"`
+	Services map[RegionCode]map[string]*Availability `json:"services"` // Region → service name → availability
+	Zones    map[string]*Availability                `json:"zones"`
+}
+
+// Availability contains availability info for a single service or zone
+type Availability struct {
+	Period      time.Duration `json:"period"`      // Duration of the period
+	Unavailable time.Duration `json:"unavailable"` // Total time of unavailability
+	Minor       time.Duration `json:"minor"`       // Total time of minor problems (excluding unavailability)
+	MinorWeight float64       `json:"minorWeight"` // Weight of minor problems in downtime
+	Incidents   int           `json:"incidents"`   // Number of incidents
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// interval is time interval
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// intervals is a slice with time intervals
+type intervals []interval
+
+// serviceLocation is service affected by incident in a region
+type serviceLocation struct {
+	region RegionCode
+	name   string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Availability calculates availability of services and zones affected by incidents
+// in a given period. Overlapping incidents are merged, so every moment of downtime
+// is counted only once. Unavailable-level incidents take precedence over minor
+// ones. Services are grouped by regions, so services with the same name in
+// different regions are counted separately. Region of service is taken from
+// its installation code or zones, if they aren't set, service is counted in all
+// regions affected by incident. Services affected by incidents without regions
+// are grouped under empty region code.
+func (i Incidents) Availability(from, to time.Time, opts AvailabilityOptions) *AvailabilityReport {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	opts.MinorWeight = min(max(opts.MinorWeight, 0), 1)
+
+	report := &AvailabilityReport{
+		From:     from,
+		To:       to,
+		Services: map[RegionCode]map[string]*Availability{},
+		Zones:    map[string]*Availability{},
+	}
+
+	if !to.After(from) {
+		return report
+	}
+
+	serviceIncidents := map[RegionCode]map[string]Incidents{}
+	zoneIncidents := map[string]Incidents{}
+
+	inPeriod := func(ii *Incident) bool {
+		return ii.overlaps(from, to, opts.Now)
+	}
+
+	for _, ii := range i.Filter(inPeriod) {
+		for _, l := range ii.serviceLocations() {
+			if serviceIncidents[l.region] == nil {
+				serviceIncidents[l.region] = map[string]Incidents{}
+			}
+
+			serviceIncidents[l.region][l.name] = append(serviceIncidents[l.region][l.name], ii)
+		}
+
+		for _, z := range uniqueStrings(ii.ZoneList()) {
+			zoneIncidents[z] = append(zoneIncidents[z], ii)
+		}
+	}
+
+	for r, services := range serviceIncidents {
+		report.Services[r] = map[string]*Availability{}
+
+		for s, incidents := range services {
+			report.Services[r][s] = calcAvailability(incidents, from, to, opts)
+		}
+	}
+
+	for z, incidents := range zoneIncidents {
+		report.Zones[z] = calcAvailability(incidents, from, to, opts)
+	}
+
+	return report
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Service returns availability of service with given name in given region. If
+// service wasn't affected by any incident, it returns 100% availability.
+func (r *AvailabilityReport) Service(name string, region RegionCode) *Availability {
+	if r == nil {
+		return nil
+	}
+
+	if r.Services[region][name] != nil {
+		return r.Services[region][name]
+	}
+
+	return &Availability{Period: r.To.Sub(r.From)}
+}
+
+// Zone returns availability of given zone. If zone wasn't affected by any
+// incident, it returns 100% availability.
+func (r *AvailabilityReport) Zone(zone string) *Availability {
+	if r == nil {
+		return nil
+	}
+
+	if r.Zones[zone] != nil {
+		return r.Zones[zone]
+	}
+
+	return &Availability{Period: r.To.Sub(r.From)}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Downtime returns weighted downtime
+func (a *Availability) Downtime() time.Duration {
+	if a == nil {
+		return 0
+	}
+
+	return a.Unavailable + time.Duration(float64(a.Minor)*a.MinorWeight)
+}
+
+// Percent returns availability in percents
+func (a *Availability) Percent() float64 {
+	if a == nil || a.Period <= 0 {
+		return 0
+	}
+
+	return (1 - float64(a.Downtime())/float64(a.Period)) * 100
+}
+
+// MeetsSLA returns true if availability is equal or greater than given target
+// (in percents, e.g. 99.95)
+func (a *Availability) MeetsSLA(target float64) bool {
+	return a != nil && a.Percent() >= target
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// serviceLocations returns unique pairs of region and name of services affected
+// by incident. Service is limited to a region by its installation code or zones,
+// otherwise it is affected in all regions of incident. If incident has no
+// regions, services have empty region code.
+func (i *Incident) serviceLocations() []serviceLocation {
+	var result []serviceLocation
+
+	incidentRegions := []RegionCode{""}
+
+	if regions := uniqueStrings(i.RegionList()); len(regions) != 0 {
+		incidentRegions = nil
+
+		for _, r := range regions {
+			incidentRegions = append(incidentRegions, RegionCode(r))
+		}
+	}
+
+	for _, s := range i.Services {
+		if s == nil {
+			continue
+		}
+
+		regions := serviceRegions(s)
+
+		if len(regions) == 0 {
+			regions = incidentRegions
+		}
+
+		for _, r := range regions {
+			if l := (serviceLocation{r, s.Name}); !slices.Contains(result, l) {
+				result = append(result, l)
+			}
+		}
+	}
+
+	return result
+}
+
+// serviceRegions returns regions of service defined by its installation code
+// or zones
+func serviceRegions(s *Service) []RegionCode {
+	if s.InstallationCode != "" {
+		return []RegionCode{s.InstallationCode}
+	}
+
+	var result []RegionCode
+
+	for _, z := range s.Zones {
+		if z == nil {
+			continue
+		}
+
+		region := ZoneRegion(z.ID)
+
+		if region == "" && z.Region != nil {
+			region = z.Region.Code
+		}
+
+		if region != "" && !slices.Contains(result, region) {
+			result = append(result, region)
+		}
+	}
+
+	return result
+}
+
+// calcAvailability calculates availability for given incidents
+func calcAvailability(incidents Incidents, from, to time.Time, opts AvailabilityOptions) *Availability {
+	var unavailable, minor intervals
+
+	for _, ii := range incidents {
+		start, end := ii.interval(opts.Now)
+		iv := interval{start, end}.clamp(from, to)
+
+		if iv.duration() == 0 {
+			continue
+		}
+
+		if ii.LevelID == LEVEL_ID_UNAVAILABLE {
+			unavailable = append(unavailable, iv)
+		} else {
+			minor = append(minor, iv)
+		}
+	}
+
+	unavailable = unavailable.merge()
+	minor = minor.merge().subtract(unavailable)
+
+	return &Availability{
+		Period:      to.Sub(from),
+		Unavailable: unavailable.duration(),
+		Minor:       minor.duration(),
+		MinorWeight: opts.MinorWeight,
+		Incidents:   len(incidents),
+	}
+}
+
+// uniqueStrings returns slice without duplicates
+func uniqueStrings(items []string) []string {
+	var result []string
+
+	for _, item := range items {
+		if !slices.Contains(result, item) {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// clamp limits interval to given period
+func (i interval) clamp(from, to time.Time) interval {
+	if i.start.Before(from) {
+		i.start = from
+	}
+
+	if i.end.After(to) {
+		i.end = to
+	}
+
+	return i
+}
+
+// duration returns interval duration
+func (i interval) duration() time.Duration {
+	return max(i.end.Sub(i.start), 0)
+}
+
+// merge merges overlapping intervals
+func (i intervals) merge() intervals {
+	if len(i) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(i)
+
+	slices.SortFunc(sorted, func(a, b interval) int {
+		return a.start.Compare(b.start)
+	})
+
+	result := intervals{sorted[0]}
+
+	for _, iv := range sorted[1:] {
+		last := &result[len(result)-1]
+
+		if iv.start.After(last.end) {
+			result = append(result, iv)
+		} else if iv.end.After(last.end) {
+			last.end = iv.end
+		}
+	}
+
+	return result
+}
+
+// subtract returns parts of merged intervals which don't overlap with given
+// merged intervals
+func (i intervals) subtract(other intervals) intervals {
+	var result intervals
+
+	for _, iv := range i {
+		for _, o := range other {
+			if !o.end.After(iv.start) || !o.start.Before(iv.end) {
+				continue
+			}
+
+			if o.start.After(iv.start) {
+				result = append(result, interval{iv.start, o.start})
+			}
+
+			iv.start = o.end
+		}
+
+		if iv.end.After(iv.start) {
+			result = append(result, iv)
+		}
+	}
+
+	return result
+}
+
+// duration returns total duration of all intervals
+func (i intervals) duration() time.Duration {
+	var result time.Duration
+
+	for _, iv := range i {
+		result += iv.duration()
+	}
+
+	return result
+}
@@ -0,0 +1,239 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Category is service category ID
+type Category uint
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	CATEGORY_OTHER          Category = 0
+	CATEGORY_DATA_PLATFORM  Category = 1
+	CATEGORY_INFRASTRUCTURE Category = 2
+	CATEGORY_SERVERLESS     Category = 3
+	CATEGORY_ML_AI          Category = 4
+	CATEGORY_RESOURCES      Category = 15
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// AllCategories is a slice with all known categories
+var AllCategories = []Category{
+	CATEGORY_INFRASTRUCTURE, CATEGORY_DATA_PLATFORM, CATEGORY_SERVERLESS,
+	CATEGORY_ML_AI, CATEGORY_RESOURCES, CATEGORY_OTHER,
+}
+
+// categoryNames contains localized category names
+var categoryNames = map[Category]map[Lang]string{
+	CATEGORY_OTHER: {
+		LANG_RU: "Другие сервисы",
+		LANG_EN: "Other services",
+	},
+	CATEGORY_DATA_PLATFORM: {
+		LANG_RU: "Платформа данных",
+		LANG_EN: "Data Platform",
+	},
+	CATEGORY_INFRASTRUCTURE: {
+		LANG_RU: "Инфраструктура и сеть",
+		LANG_EN: "Infrastructure & Network",
+	},
+	CATEGORY_SERVERLESS: {
+		LANG_RU: "Бессерверные вычисления",
+		LANG_EN: "Serverless computing",
+	},
+	CATEGORY_ML_AI: {
+		LANG_RU: "Машинное обучение и искусственный интеллект",
+		LANG_EN: "Machine Learning & AI",
+	},
+	CATEGORY_RESOURCES: {
+		LANG_RU: "Управление ресурсами",
+		LANG_EN: "Resource management",
+	},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsKnown returns true if category is known
+func (c Category) IsKnown() bool {
+	return categoryNames[c] != nil
+}
+
+// Name returns localized category name
+func (c Category) Name(lang Lang) string {
+	names := categoryNames[c]
+
+	if names == nil {
+		return fmt.Sprintf("%s #%d", CATEGORY_OTHER.Name(lang), c)
+	}
+
+	name := names[lang]
+
+	if name == "" {
+		return names[LANG_EN]
+	}
+
+	return name
+}
+
+// String returns category name in English
+func (c Category) String() string {
+	return c.Name(LANG_EN)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Category returns service category
+func (s *Service) Category() Category {
+	if s == nil {
+		return CATEGORY_OTHER
+	}
+
+	return Category(s.CategoryID)
+}
+
+// ByCategory groups services by category
+func (s Services) ByCategory() map[Category]Services {
+	if len(s) == 0 {
+		return nil
+	}
+
+	result := map[Category]Services{}
+
+	for _, ss := range s {
+		if ss != nil {
+			result[ss.Category()] = append(result[ss.Category()], ss)
+		}
+	}
+
+	return result
+}
+
+// Categories returns sorted slice with categories of all services
+func (s Services) Categories() []Category {
+	var result []Category
+
+	for _, ss := range s {
+		if ss != nil && !slices.Contains(result, ss.Category()) {
+			result = append(result, ss.Category())
+		}
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+// ByCategory groups incidents by categories of affected services. Categories
+// are resolved using given services (from GetServices) by service ID and
+// region, because incidents data usually doesn't contain service categories.
+// Incident affecting services from different categories is added to every
+// group.
+func (i Incidents) ByCategory(services Services) map[Category]Incidents {
+	if len(i) == 0 {
+		return nil
+	}
+
+	lookup := newCategoryLookup(services)
+	result := map[Category]Incidents{}
+
+	for _, ii := range i {
+		if ii == nil {
+			continue
+		}
+
+		var categories []Category
+
+		for _, ss := range ii.Services {
+			if ss == nil {
+				continue
+			}
+
+			c := lookup.category(ss, ii.Regions)
+
+			if !slices.Contains(categories, c) {
+				categories = append(categories, c)
+			}
+		}
+
+		for _, c := range categories {
+			result[c] = append(result[c], ii)
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// categoryKey is key for category lookup
+type categoryKey struct {
+	id     uint
+	region RegionCode
+}
+
+// categoryLookup contains categories of services
+type categoryLookup struct {
+	byKey map[categoryKey]Category
+	byID  map[uint]Category
+}
+
+// newCategoryLookup creates new category lookup from given services
+func newCategoryLookup(services Services) *categoryLookup {
+	l := &categoryLookup{
+		byKey: map[categoryKey]Category{},
+		byID:  map[uint]Category{},
+	}
+
+	for _, s := range services {
+		if s == nil {
+			continue
+		}
+
+		l.byKey[categoryKey{s.ID, s.InstallationCode}] = s.Category()
+
+		if _, ok := l.byID[s.ID]; !ok {
+			l.byID[s.ID] = s.Category()
+		}
+	}
+
+	return l
+}
+
+// category returns category of service affected by incident in given regions.
+// If service is unknown, category from service data is used.
+func (l *categoryLookup) category(s *Service, regions Regions) Category {
+	if s.InstallationCode != "" {
+		if c, ok := l.byKey[categoryKey{s.ID, s.InstallationCode}]; ok {
+			return c
+		}
+	}
+
+	for _, r := range regions {
+		if r == nil {
+			continue
+		}
+
+		if c, ok := l.byKey[categoryKey{s.ID, r.Code}]; ok {
+			return c
+		}
+	}
+
+	if c, ok := l.byID[s.ID]; ok {
+		return c
+	}
+
+	return s.Category()
+}
@@ -0,0 +1,318 @@
+package digest
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmlTemplate "html/template"
+	"slices"
+	"strings"
+	textTemplate "text/template"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	"github.com/essentialkaos/ycs"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// OTHER_SERVICES is name of group for incidents without services
+const OTHER_SERVICES = "Other"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Digest contains incidents for a period grouped by service
+type Digest struct {
+	From   time.Time
+	To     time.Time
+	Lang   ycs.Lang
+	Groups []*Group
+
+	now time.Time
+}
+
+// Group contains incidents affected a service
+type Group struct {
+	Service   string
+	Incidents ycs.Incidents
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// item is incident info used in templates
+type item struct {
+	Title     string
+	URL       string
+	Level     string
+	Status    string
+	Start     string
+	Duration  string
+	Zones     string
+	Critical  bool
+	HasReport bool
+}
+
+// group is group info used in templates
+type group struct {
+	Service string
+	Items   []*item
+}
+
+// templateData is data used in templates
+type templateData struct {
+	Period string
+	Total  int
+	Groups []*group
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var textTmpl = textTemplate.Must(textTemplate.New("text").Parse(
+	`Yandex Cloud incidents digest for {{.Period}}
+{{if eq .Total 0}}
+No incidents.
+{{else}}
+Total incidents: {{.Total}}
+{{range .Groups}}
+{{.Service}}
+{{range .Items}}
+  - {{.Title}}
+    Level: {{.Level}} | Status: {{.Status}} | Duration: {{.Duration}}
+    Start: {{.Start}}{{if .Zones}} | Zones: {{.Zones}}{{end}}
+    {{if .HasReport}}Report{{else}}Details{{end}}: {{.URL}}
+{{end}}{{end}}{{end}}`,
+))
+
+var htmlTmpl = htmlTemplate.Must(htmlTemplate.New("html").Parse(
+	`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Yandex Cloud incidents digest</title></head>
+<body style="font-family:Arial,Helvetica,sans-serif;font-size:14px;color:#222">
+<h2>Yandex Cloud incidents digest for {{.Period}}</h2>
+{{if eq .Total 0}}<p>No incidents.</p>{{else}}<p>Total incidents: <b>{{.Total}}</b></p>
+{{range .Groups}}<h3>{{.Service}}</h3>
+<table cellpadding="6" cellspacing="0" border="1" style="border-collapse:collapse;border-color:#ddd">
+<tr style="background:#f5f5f5"><th align="left">Incident</th><th align="left">Level</th><th align="left">Status</th><th align="left">Start</th><th align="left">Duration</th><th align="left">Zones</th></tr>
+{{range .Items}}<tr>
+<td><a href="{{.URL}}">{{.Title}}</a>{{if .HasReport}} (<a href="{{.URL}}">report</a>){{end}}</td>
+<td{{if .Critical}} style="color:#c00"{{end}}>{{.Level}}</td>
+<td>{{.Status}}</td>
+<td>{{.Start}}</td>
+<td>{{.Duration}}</td>
+<td>{{.Zones}}</td>
+</tr>
+{{end}}</table>
+{{end}}{{end}}</body>
+</html>
+`,
+))
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Collect fetches incidents for given period from all pages and creates digest.
+// If client is nil, default client is used.
+func Collect(ctx context.Context, client *ycs.Client, from, to time.Time, lang ycs.Lang) (*Digest, error) {
+	req := ycs.IncidentsRequest{Lang: lang, From: from, To: to}
+	seq := ycs.IterIncidentsContext(ctx, req)
+
+	if client != nil {
+		seq = client.IterIncidentsContext(ctx, req)
+	}
+
+	var incidents ycs.Incidents
+
+	for i, err := range seq {
+		if err != nil {
+			return nil, fmt.Errorf("Can't get incidents: %w", err)
+		}
+
+		incidents = append(incidents, i)
+	}
+
+	return New(incidents, from, to, lang), nil
+}
+
+// New creates digest with incidents which were active in given period
+func New(incidents ycs.Incidents, from, to time.Time, lang ycs.Lang) *Digest {
+	d := &Digest{From: from, To: to, Lang: lang, now: time.Now()}
+	index := map[string]*Group{}
+
+	for _, i := range incidents.Filter(ycs.Between(from, to)).SortByStartDate() {
+		services := i.ServiceList()
+
+		if len(services) == 0 {
+			services = []string{OTHER_SERVICES}
+		}
+
+		for _, s := range services {
+			g := index[s]
+
+			if g == nil {
+				g = &Group{Service: s}
+				index[s] = g
+				d.Groups = append(d.Groups, g)
+			}
+
+			if !slices.Contains(g.Incidents, i) {
+				g.Incidents = append(g.Incidents, i)
+			}
+		}
+	}
+
+	slices.SortFunc(d.Groups, func(a, b *Group) int {
+		switch {
+		case a.Service == OTHER_SERVICES:
+			return 1
+		case b.Service == OTHER_SERVICES:
+			return -1
+		}
+
+		return strings.Compare(strings.ToLower(a.Service), strings.ToLower(b.Service))
+	})
+
+	return d
+}
+
+// Daily returns period of the previous day for given time
+func Daily(now time.Time) (time.Time, time.Time) {
+	to := timeutil.StartOfDay(now)
+	return to.AddDate(0, 0, -1), to
+}
+
+// Weekly returns period of the previous week (Monday to Monday) for given time
+func Weekly(now time.Time) (time.Time, time.Time) {
+	to := timeutil.StartOfWeek(now, time.Monday)
+	return to.AddDate(0, 0, -7), to
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Count returns number of unique incidents in digest
+func (d *Digest) Count() int {
+	if d == nil {
+		return 0
+	}
+
+	seen := map[uint]bool{}
+
+	for _, g := range d.Groups {
+		for _, i := range g.Incidents {
+			seen[i.ID] = true
+		}
+	}
+
+	return len(seen)
+}
+
+// Subject returns email subject
+func (d *Digest) Subject() string {
+	if d == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("Yandex Cloud incidents digest for %s (%d)", d.period(), d.Count())
+}
+
+// Text renders digest as plain text
+func (d *Digest) Text() (string, error) {
+	if d == nil {
+		return "", fmt.Errorf("Digest is nil")
+	}
+
+	var buf bytes.Buffer
+
+	err := textTmpl.Execute(&buf, d.templateData())
+
+	if err != nil {
+		return "", fmt.Errorf("Can't render text digest: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// HTML renders digest as HTML
+func (d *Digest) HTML() (string, error) {
+	if d == nil {
+		return "", fmt.Errorf("Digest is nil")
+	}
+
+	var buf bytes.Buffer
+
+	err := htmlTmpl.Execute(&buf, d.templateData())
+
+	if err != nil {
+		return "", fmt.Errorf("Can't render HTML digest: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// period returns formatted digest period
+func (d *Digest) period() string {
+	from := d.From.Format("2006-01-02")
+	to := d.To.Add(-time.Nanosecond).Format("2006-01-02")
+
+	if from == to {
+		return from
+	}
+
+	return from + " – " + to
+}
+
+// templateData returns data for templates
+func (d *Digest) templateData() *templateData {
+	data := &templateData{Period: d.period(), Total: d.Count()}
+
+	now := d.now
+
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	for _, g := range d.Groups {
+		tg := &group{Service: g.Service}
+
+		for _, i := range g.Incidents {
+			tg.Items = append(tg.Items, &item{
+				Title:     i.Title,
+				URL:       i.URL(d.Lang),
+				Level:     i.LevelID.Label(d.Lang),
+				Status:    i.Status.Label(d.Lang),
+				Start:     i.StartDate.In(d.From.Location()).Format("2006-01-02 15:04 MST"),
+				Duration:  formatDuration(i, now),
+				Zones:     strings.Join(i.ZoneList(), ", "),
+				Critical:  i.LevelID == ycs.LEVEL_ID_UNAVAILABLE,
+				HasReport: i.IsReportPublished,
+			})
+		}
+
+		data.Groups = append(data.Groups, tg)
+	}
+
+	return data
+}
+
+// formatDuration returns formatted incident duration
+func formatDuration(i *ycs.Incident, now time.Time) string {
+	if i.EndDate.IsZero() {
+		return timeutil.Pretty(now.Sub(i.StartDate.Time).Truncate(time.Minute)).String() + " (ongoing)"
+	}
+
+	d := i.Duration().Truncate(time.Minute)
+
+	if d < time.Minute {
+		return "less than a minute"
+	}
+
+	return timeutil.Pretty(d).String()
+}
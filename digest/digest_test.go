@@ -0,0 +1,501 @@
+package digest
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/ycs"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type DigestSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&DigestSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// smtpStub is a local stand-in for SMTP server
+type smtpStub struct {
+	ln        net.Listener
+	tlsConfig *tls.Config
+	username  string
+	password  string
+
+	messages []*smtpMessage
+	mu       sync.Mutex
+}
+
+// smtpMessage is message received by SMTP stand-in
+type smtpMessage struct {
+	From string
+	To   []string
+	Data []byte
+	TLS  bool
+	Auth bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *DigestSuite) TestDigest(c *C) {
+	incidents := loadIncidents(c)
+
+	from, to := Weekly(time.Date(2024, 12, 25, 15, 0, 0, 0, time.UTC))
+
+	c.Assert(from, Equals, time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC))
+	c.Assert(to, Equals, time.Date(2024, 12, 23, 0, 0, 0, 0, time.UTC))
+
+	d := New(incidents, from, to, ycs.LANG_EN)
+
+	c.Assert(d.Count(), Equals, 2)
+	c.Assert(d.Groups, HasLen, 81)
+	c.Assert(d.Groups[0].Service, Equals, "API Gateway")
+	c.Assert(d.Groups[0].Incidents, HasLen, 1)
+	c.Assert(d.Groups[0].Incidents[0].ID, Equals, uint(1013))
+
+	for _, g := range d.Groups {
+		if g.Service == "Managed Service for ClickHouse®" {
+			c.Assert(g.Incidents, HasLen, 2)
+			c.Assert(g.Incidents[0].ID, Equals, uint(1012))
+			c.Assert(g.Incidents[1].ID, Equals, uint(1013))
+		}
+	}
+	c.Assert(d.Subject(), Equals, "Yandex Cloud incidents digest for 2024-12-16 – 2024-12-22 (2)")
+
+	text, err := d.Text()
+
+	c.Assert(err, IsNil)
+	c.Assert(text, Matches, `(?s)Yandex Cloud incidents digest for 2024-12-16 – 2024-12-22\n\nTotal incidents: 2\n\nAPI Gateway\n.*`)
+	c.Assert(strings.Contains(text, "Duration: 1 hour and 22 minutes"), Equals, true)
+	c.Assert(strings.Contains(text, "Report: https://status.yandex.cloud/en/incidents/1013"), Equals, true)
+	c.Assert(strings.Contains(text, "Details: https://status.yandex.cloud/en/incidents/1012"), Equals, true)
+
+	html, err := d.HTML()
+
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(html, `<h3>Managed Service for ClickHouse®</h3>`), Equals, true)
+	c.Assert(strings.Contains(html, `<a href="https://status.yandex.cloud/en/incidents/1012">`), Equals, true)
+
+	from, to = Daily(time.Date(2024, 12, 20, 9, 0, 0, 0, time.UTC))
+	d = New(incidents, from, to, ycs.LANG_EN)
+
+	c.Assert(d.Count(), Equals, 1)
+	c.Assert(d.Subject(), Equals, "Yandex Cloud incidents digest for 2024-12-19 (1)")
+
+	d = New(incidents, to.AddDate(1, 0, 0), to.AddDate(1, 0, 1), ycs.LANG_EN)
+	text, _ = d.Text()
+	html, _ = d.HTML()
+
+	c.Assert(d.Count(), Equals, 0)
+	c.Assert(strings.Contains(text, "No incidents."), Equals, true)
+	c.Assert(strings.Contains(html, "<p>No incidents.</p>"), Equals, true)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	d = New(ycs.Incidents{
+		{ID: 1, Title: "Open <b>", StartDate: ycs.Date{Time: now.Add(-90 * time.Minute)}},
+		{ID: 2, Title: "Short", StartDate: ycs.Date{Time: now.Add(-time.Hour)}, EndDate: ycs.Date{Time: now.Add(-time.Hour + time.Second)}},
+	}, now.Add(-24*time.Hour), now, ycs.LANG_EN)
+	d.now = now
+
+	c.Assert(d.Groups, HasLen, 1)
+	c.Assert(d.Groups[0].Service, Equals, OTHER_SERVICES)
+
+	text, _ = d.Text()
+	html, _ = d.HTML()
+
+	c.Assert(strings.Contains(text, "Duration: 1 hour and 30 minutes (ongoing)"), Equals, true)
+	c.Assert(strings.Contains(text, "Duration: less than a minute"), Equals, true)
+	c.Assert(strings.Contains(html, "Open &lt;b&gt;"), Equals, true)
+
+	d = nil
+
+	_, err = d.Text()
+	c.Assert(err, NotNil)
+	_, err = d.HTML()
+	c.Assert(err, NotNil)
+	_, err = d.Message("", nil)
+	c.Assert(err, NotNil)
+	c.Assert(d.Send(context.Background(), SMTPConfig{}), NotNil)
+	c.Assert(d.Count(), Equals, 0)
+	c.Assert(d.Subject(), Equals, "")
+}
+
+func (s *DigestSuite) TestCollect(c *C) {
+	data, err := os.ReadFile("../testdata/incidents.json")
+	c.Assert(err, IsNil)
+
+	resp := &struct {
+		Items []json.RawMessage `json:"items"`
+		Count int               `json:"count"`
+	}{}
+
+	c.Assert(json.Unmarshal(data, resp), IsNil)
+
+	var requests atomic.Int32
+
+	// API stand-in returns one incident per page
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/incidents" {
+			rw.WriteHeader(404)
+			return
+		}
+
+		requests.Add(1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		from := min(max(page-1, 0), len(resp.Items))
+
+		pageData, _ := json.Marshal(map[string]any{
+			"items": resp.Items[from:min(from+1, len(resp.Items))],
+			"count": resp.Count,
+		})
+
+		rw.Write(pageData)
+	}))
+
+	defer server.Close()
+
+	client := ycs.NewClient()
+	client.SetURL(server.URL)
+
+	from, to := Weekly(time.Date(2024, 12, 25, 15, 0, 0, 0, time.UTC))
+	d, err := Collect(context.Background(), client, from, to, ycs.LANG_EN)
+
+	c.Assert(err, IsNil)
+	c.Assert(d.Count(), Equals, 2)
+	c.Assert(int(requests.Load()), Equals, len(resp.Items)+1)
+
+	client.SetURL(server.URL + "/unknown")
+	_, err = Collect(context.Background(), client, from, to, ycs.LANG_EN)
+
+	c.Assert(err, NotNil)
+}
+
+func (s *DigestSuite) TestMessage(c *C) {
+	from, to := Weekly(time.Date(2024, 12, 25, 15, 0, 0, 0, time.UTC))
+	d := New(loadIncidents(c), from, to, ycs.LANG_EN)
+
+	msg, err := d.Message("Status Bot <status@domain.com>", []string{"boss@domain.com", "cto@domain.com"})
+	c.Assert(err, IsNil)
+
+	text, html := parseMessage(c, msg)
+
+	c.Assert(strings.Contains(text, "Total incidents: 2"), Equals, true)
+	c.Assert(strings.Contains(html, "<h3>API Gateway</h3>"), Equals, true)
+}
+
+func (s *DigestSuite) TestConfigValidation(c *C) {
+	c.Assert(SMTPConfig{}.Validate(), ErrorMatches, "SMTP host must be set")
+	c.Assert(SMTPConfig{Host: "127.0.0.1", Port: 100000}.Validate(), ErrorMatches, "Invalid SMTP port 100000")
+	c.Assert(SMTPConfig{Host: "127.0.0.1"}.Validate(), ErrorMatches, "Sender address must be set")
+	c.Assert(SMTPConfig{Host: "127.0.0.1", From: "a@domain.com"}.Validate(), ErrorMatches, "At least one recipient must be set")
+	c.Assert(SMTPConfig{Host: "127.0.0.1", From: "ABCD", To: []string{"b@domain.com"}}.Validate(), ErrorMatches, `Invalid sender address "ABCD".*`)
+	c.Assert(SMTPConfig{Host: "127.0.0.1", From: "a@domain.com", To: []string{"ABCD"}}.Validate(), ErrorMatches, `Invalid recipient address "ABCD".*`)
+	c.Assert(SMTPConfig{Host: "127.0.0.1", From: "a@domain.com", To: []string{"b@domain.com"}}.Validate(), IsNil)
+}
+
+func (s *DigestSuite) TestSend(c *C) {
+	stub, pool := startSMTPStub(c)
+	defer stub.ln.Close()
+
+	host, port, _ := net.SplitHostPort(stub.ln.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+
+	from, to := Weekly(time.Date(2024, 12, 25, 15, 0, 0, 0, time.UTC))
+	d := New(loadIncidents(c), from, to, ycs.LANG_EN)
+
+	cfg := SMTPConfig{
+		Host:      host,
+		Port:      portNum,
+		Username:  "bot",
+		Password:  "Test1234",
+		From:      "Status Bot <status@domain.com>",
+		To:        []string{"boss@domain.com", "CTO <cto@domain.com>"},
+		StartTLS:  true,
+		TLSConfig: &tls.Config{RootCAs: pool, ServerName: host},
+		Timeout:   5 * time.Second,
+	}
+
+	c.Assert(d.Send(context.Background(), cfg), IsNil)
+	c.Assert(stub.messages, HasLen, 1)
+
+	msg := stub.messages[0]
+
+	c.Assert(msg.TLS, Equals, true)
+	c.Assert(msg.Auth, Equals, true)
+	c.Assert(msg.From, Equals, "status@domain.com")
+	c.Assert(msg.To, DeepEquals, []string{"boss@domain.com", "cto@domain.com"})
+
+	text, _ := parseMessage(c, msg.Data)
+	c.Assert(strings.Contains(text, "Total incidents: 2"), Equals, true)
+
+	badCfg := cfg
+	badCfg.Password = "ABCD"
+	c.Assert(d.Send(context.Background(), badCfg), ErrorMatches, "Can't authenticate: .*")
+
+	badCfg = cfg
+	badCfg.TLSConfig = nil
+	c.Assert(d.Send(context.Background(), badCfg), ErrorMatches, "Can't start TLS: .*")
+
+	badCfg = cfg
+	badCfg.To = []string{"unknown@domain.com"}
+	c.Assert(d.Send(context.Background(), badCfg), ErrorMatches, "Can't add recipient unknown@domain.com: .*")
+
+	badCfg = cfg
+	badCfg.Port = 1
+	c.Assert(d.Send(context.Background(), badCfg), ErrorMatches, "Can't connect to SMTP server: .*")
+
+	stub.tlsConfig = nil
+	c.Assert(d.Send(context.Background(), cfg), ErrorMatches, "SMTP server doesn't support STARTTLS")
+
+	cfg.StartTLS = false
+	c.Assert(d.Send(context.Background(), cfg), IsNil)
+	c.Assert(stub.messages, HasLen, 2)
+	c.Assert(stub.messages[1].TLS, Equals, false)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func startSMTPStub(c *C) (*smtpStub, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, IsNil)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	c.Assert(err, IsNil)
+
+	cert, err := x509.ParseCertificate(der)
+	c.Assert(err, IsNil)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+
+	stub := &smtpStub{
+		ln:       ln,
+		username: "bot",
+		password: "Test1234",
+		tlsConfig: &tls.Config{
+			Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		},
+	}
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go stub.serve(conn)
+		}
+	}()
+
+	return stub, pool
+}
+
+func (s *smtpStub) serve(conn net.Conn) {
+	defer conn.Close()
+
+	tp := newTextConn(conn)
+	msg := &smtpMessage{}
+
+	tp.reply("220 localhost ESMTP")
+
+	for {
+		line, err := tp.ReadLine()
+
+		if err != nil {
+			return
+		}
+
+		cmd, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(cmd) {
+		case "EHLO", "HELO":
+			if s.tlsConfig != nil && !msg.TLS {
+				tp.reply("250-localhost\r\n250-STARTTLS\r\n250 AUTH PLAIN")
+			} else {
+				tp.reply("250-localhost\r\n250 AUTH PLAIN")
+			}
+
+		case "STARTTLS":
+			tp.reply("220 Ready to start TLS")
+
+			tlsConn := tls.Server(conn, s.tlsConfig)
+
+			if tlsConn.Handshake() != nil {
+				return
+			}
+
+			conn, tp, msg.TLS = tlsConn, newTextConn(tlsConn), true
+
+		case "AUTH":
+			_, cred, _ := strings.Cut(arg, " ")
+			data, _ := base64.StdEncoding.DecodeString(cred)
+
+			if string(data) != "\x00"+s.username+"\x00"+s.password {
+				tp.reply("535 Authentication failed")
+				continue
+			}
+
+			msg.Auth = true
+			tp.reply("235 Authentication succeeded")
+
+		case "MAIL":
+			msg.From = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			tp.reply("250 OK")
+
+		case "RCPT":
+			addr := strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+
+			if strings.HasPrefix(addr, "unknown") {
+				tp.reply("550 No such user")
+				continue
+			}
+
+			msg.To = append(msg.To, addr)
+			tp.reply("250 OK")
+
+		case "DATA":
+			tp.reply("354 Start mail input")
+
+			data, err := tp.ReadDotBytes()
+
+			if err != nil {
+				return
+			}
+
+			msg.Data = data
+
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+
+			tp.reply("250 OK")
+
+		case "QUIT":
+			tp.reply("221 Bye")
+			return
+
+		default:
+			tp.reply("502 Command not implemented")
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func loadIncidents(c *C) ycs.Incidents {
+	data, err := os.ReadFile("../testdata/incidents.json")
+	c.Assert(err, IsNil)
+
+	page := &ycs.IncidentsPage{}
+	c.Assert(json.Unmarshal(data, page), IsNil)
+
+	return page.Items
+}
+
+func parseMessage(c *C, data []byte) (string, string) {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	c.Assert(err, IsNil)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	c.Assert(err, IsNil)
+	c.Assert(subject, Equals, "Yandex Cloud incidents digest for 2024-12-16 – 2024-12-22 (2)")
+
+	c.Assert(msg.Header.Get("From"), Equals, "Status Bot <status@domain.com>")
+	c.Assert(msg.Header.Get("MIME-Version"), Equals, "1.0")
+	c.Assert(msg.Header.Get("Message-ID"), Matches, `<[0-9a-f]{24}@domain\.com>`)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	c.Assert(err, IsNil)
+	c.Assert(mediaType, Equals, "multipart/alternative")
+
+	var parts []string
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+
+	for {
+		p, err := mr.NextPart()
+
+		if err == io.EOF {
+			break
+		}
+
+		c.Assert(err, IsNil)
+
+		data, err := io.ReadAll(p) // quoted-printable is decoded by multipart reader
+		c.Assert(err, IsNil)
+
+		parts = append(parts, p.Header.Get("Content-Type")+"\n"+string(data))
+	}
+
+	c.Assert(parts, HasLen, 2)
+	c.Assert(strings.HasPrefix(parts[0], "text/plain; charset=utf-8\n"), Equals, true)
+	c.Assert(strings.HasPrefix(parts[1], "text/html; charset=utf-8\n"), Equals, true)
+
+	return parts[0], parts[1]
+}
+
+// textConn is text protocol connection
+type textConn struct {
+	*textproto.Conn
+}
+
+func newTextConn(conn net.Conn) *textConn {
+	return &textConn{textproto.NewConn(conn)}
+}
+
+func (c *textConn) reply(text string) {
+	c.PrintfLine("%s", text)
+}
@@ -0,0 +1,278 @@
+package digest
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SMTPConfig contains SMTP server configuration
+type SMTPConfig struct {
+	Host     string // SMTP server host
+	Port     int    // SMTP server port (587 by default)
+	Username string // Username for PLAIN authentication
+	Password string // Password for PLAIN authentication
+
+	From string   // Sender address
+	To   []string // Recipients addresses
+
+	// StartTLS enables STARTTLS. If true and server doesn't support STARTTLS,
+	// message won't be sent.
+	StartTLS bool
+
+	// TLSConfig is TLS configuration used for STARTTLS
+	TLSConfig *tls.Config
+
+	// Timeout is connection timeout (30 seconds by default)
+	Timeout time.Duration
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate validates SMTP configuration
+func (c SMTPConfig) Validate() error {
+	switch {
+	case c.Host == "":
+		return fmt.Errorf("SMTP host must be set")
+	case c.Port < 0 || c.Port > 65535:
+		return fmt.Errorf("Invalid SMTP port %d", c.Port)
+	case c.From == "":
+		return fmt.Errorf("Sender address must be set")
+	case len(c.To) == 0:
+		return fmt.Errorf("At least one recipient must be set")
+	}
+
+	_, err := mail.ParseAddress(c.From)
+
+	if err != nil {
+		return fmt.Errorf("Invalid sender address %q: %w", c.From, err)
+	}
+
+	for _, to := range c.To {
+		_, err = mail.ParseAddress(to)
+
+		if err != nil {
+			return fmt.Errorf("Invalid recipient address %q: %w", to, err)
+		}
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Message creates multipart email message with text and HTML versions of digest
+func (d *Digest) Message(from string, to []string) ([]byte, error) {
+	if d == nil {
+		return nil, fmt.Errorf("Digest is nil")
+	}
+
+	text, err := d.Text()
+
+	if err != nil {
+		return nil, err
+	}
+
+	html, err := d.HTML()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+
+	for _, part := range []struct{ contentType, data string }{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", html},
+	} {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("Can't create message part: %w", err)
+		}
+
+		qw := quotedprintable.NewWriter(pw)
+		qw.Write([]byte(part.data))
+		qw.Close()
+	}
+
+	mw.Close()
+
+	var msg bytes.Buffer
+
+	host := "localhost"
+
+	if addr, err := mail.ParseAddress(from); err == nil {
+		if _, domain, ok := strings.Cut(addr.Address, "@"); ok {
+			host = domain
+		}
+	}
+
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", d.Subject()))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Message-ID: <%s@%s>\r\n", newMessageID(), host)
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n", mw.Boundary())
+	fmt.Fprintf(&msg, "\r\n")
+
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+// Send sends digest over SMTP
+func (d *Digest) Send(ctx context.Context, cfg SMTPConfig) error {
+	if d == nil {
+		return fmt.Errorf("Digest is nil")
+	}
+
+	err := cfg.Validate()
+
+	if err != nil {
+		return err
+	}
+
+	msg, err := d.Message(cfg.From, cfg.To)
+
+	if err != nil {
+		return err
+	}
+
+	return sendMail(ctx, cfg, msg)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// sendMail sends message over SMTP
+func sendMail(ctx context.Context, cfg SMTPConfig, msg []byte) error {
+	if cfg.Port == 0 {
+		cfg.Port = 587
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)))
+
+	if err != nil {
+		return fmt.Errorf("Can't connect to SMTP server: %w", err)
+	}
+
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	// Close connection if context is cancelled while we are talking to server
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, cfg.Host)
+
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("Can't create SMTP client: %w", err)
+	}
+
+	defer client.Close()
+
+	if cfg.StartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("SMTP server doesn't support STARTTLS")
+		}
+
+		tlsConfig := cfg.TLSConfig
+
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{ServerName: cfg.Host}
+		}
+
+		err = client.StartTLS(tlsConfig)
+
+		if err != nil {
+			return fmt.Errorf("Can't start TLS: %w", err)
+		}
+	}
+
+	if cfg.Username != "" {
+		err = client.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host))
+
+		if err != nil {
+			return fmt.Errorf("Can't authenticate: %w", err)
+		}
+	}
+
+	from, _ := mail.ParseAddress(cfg.From)
+	err = client.Mail(from.Address)
+
+	if err != nil {
+		return fmt.Errorf("Can't set sender: %w", err)
+	}
+
+	for _, to := range cfg.To {
+		addr, _ := mail.ParseAddress(to)
+		err = client.Rcpt(addr.Address)
+
+		if err != nil {
+			return fmt.Errorf("Can't add recipient %s: %w", addr.Address, err)
+		}
+	}
+
+	w, err := client.Data()
+
+	if err != nil {
+		return fmt.Errorf("Can't send message: %w", err)
+	}
+
+	_, err = w.Write(msg)
+
+	if err == nil {
+		err = w.Close()
+	}
+
+	if err != nil {
+		return fmt.Errorf("Can't send message: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// newMessageID generates random message ID
+func newMessageID() string {
+	buf := make([]byte, 12)
+	rand.Read(buf)
+
+	return hex.EncodeToString(buf)
+}
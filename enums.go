@@ -0,0 +1,303 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Lang is API language
+type Lang string
+
+// RegionCode is installation region code
+type RegionCode string
+
+// Status is incident status
+type Status string
+
+// CommentType is incident comment type
+type CommentType string
+
+// LevelID is incident level ID
+type LevelID uint8
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// labels contains localized labels for enum values
+type labels map[string]map[Lang]string
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// AllStatuses is a slice with all incident statuses
+var AllStatuses = []Status{STATUS_OPEN, STATUS_RESOLVED, STATUS_WITH_REPORT}
+
+// AllCommentTypes is a slice with all comment types
+var AllCommentTypes = []CommentType{TYPE_INVESTIGATION, TYPE_UPDATE, TYPE_RESOLVED}
+
+// AllLevels is a slice with all incident levels
+var AllLevels = []LevelID{LEVEL_ID_MINOR, LEVEL_ID_UNAVAILABLE}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var langLabels = labels{
+	LANG_RU: {LANG_RU: "Русский", LANG_EN: "Russian"},
+	LANG_EN: {LANG_RU: "Английский", LANG_EN: "English"},
+}
+
+var regionLabels = labels{
+	REGION_ALL: {LANG_RU: "Все регионы", LANG_EN: "All regions"},
+	REGION_RU:  {LANG_RU: "Россия", LANG_EN: "Russia"},
+	REGION_KZ:  {LANG_RU: "Казахстан", LANG_EN: "Kazakhstan"},
+}
+
+var statusLabels = labels{
+	STATUS_OPEN:        {LANG_RU: "Открыт", LANG_EN: "Open"},
+	STATUS_RESOLVED:    {LANG_RU: "Решён", LANG_EN: "Resolved"},
+	STATUS_WITH_REPORT: {LANG_RU: "С отчётом", LANG_EN: "With report"},
+}
+
+var commentTypeLabels = labels{
+	TYPE_INVESTIGATION: {LANG_RU: "Расследование", LANG_EN: "Investigating"},
+	TYPE_UPDATE:        {LANG_RU: "Обновление", LANG_EN: "Update"},
+	TYPE_RESOLVED:      {LANG_RU: "Решено", LANG_EN: "Resolved"},
+}
+
+var levelLabels = labels{
+	LEVEL_MINOR:       {LANG_RU: "Частичная недоступность", LANG_EN: "Minor"},
+	LEVEL_UNAVAILABLE: {LANG_RU: "Недоступность", LANG_EN: "Unavailable"},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// String returns language code
+func (l Lang) String() string {
+	return string(l)
+}
+
+// IsValid returns true if language is supported by API
+func (l Lang) IsValid() bool {
+	return slices.Contains(AllLangs, string(l))
+}
+
+// Label returns localized language name
+func (l Lang) Label(lang Lang) string {
+	return langLabels.get(string(l), lang)
+}
+
+// MarshalText encodes language to text
+func (l Lang) MarshalText() ([]byte, error) {
+	return []byte(l), nil
+}
+
+// UnmarshalText decodes language from text (case-insensitive)
+func (l *Lang) UnmarshalText(data []byte) error {
+	*l = Lang(foldValue(string(data), AllLangs))
+	return nil
+}
+
+// orDefault returns language code or default language code if language is empty
+func (l Lang) orDefault() string {
+	if l == "" {
+		return LANG_RU
+	}
+
+	return string(l)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// String returns region code
+func (r RegionCode) String() string {
+	return string(r)
+}
+
+// IsValid returns true if region code is known
+func (r RegionCode) IsValid() bool {
+	return slices.Contains(AllRegions, string(r))
+}
+
+// Label returns localized region name
+func (r RegionCode) Label(lang Lang) string {
+	return regionLabels.get(string(r), lang)
+}
+
+// MarshalText encodes region code to text
+func (r RegionCode) MarshalText() ([]byte, error) {
+	return []byte(r), nil
+}
+
+// UnmarshalText decodes region code from text (case-insensitive)
+func (r *RegionCode) UnmarshalText(data []byte) error {
+	*r = RegionCode(foldValue(string(data), AllRegions))
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// String returns status name
+func (s Status) String() string {
+	return string(s)
+}
+
+// IsValid returns true if status is known
+func (s Status) IsValid() bool {
+	return slices.Contains(AllStatuses, s)
+}
+
+// Label returns localized status name
+func (s Status) Label(lang Lang) string {
+	return statusLabels.get(string(s), lang)
+}
+
+// MarshalText encodes status to text
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText decodes status from text (case-insensitive)
+func (s *Status) UnmarshalText(data []byte) error {
+	*s = foldValue(string(data), AllStatuses)
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// String returns comment type name
+func (t CommentType) String() string {
+	return string(t)
+}
+
+// IsValid returns true if comment type is known
+func (t CommentType) IsValid() bool {
+	return slices.Contains(AllCommentTypes, t)
+}
+
+// Label returns localized comment type name
+func (t CommentType) Label(lang Lang) string {
+	return commentTypeLabels.get(string(t), lang)
+}
+
+// MarshalText encodes comment type to text
+func (t CommentType) MarshalText() ([]byte, error) {
+	return []byte(t), nil
+}
+
+// UnmarshalText decodes comment type from text (case-insensitive)
+func (t *CommentType) UnmarshalText(data []byte) error {
+	*t = foldValue(string(data), AllCommentTypes)
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// String returns level name
+func (l LevelID) String() string {
+	switch l {
+	case LEVEL_ID_MINOR:
+		return LEVEL_MINOR
+	case LEVEL_ID_UNAVAILABLE:
+		return LEVEL_UNAVAILABLE
+	}
+
+	return strconv.Itoa(int(l))
+}
+
+// IsValid returns true if level is known
+func (l LevelID) IsValid() bool {
+	return slices.Contains(AllLevels, l)
+}
+
+// Label returns localized level name
+func (l LevelID) Label(lang Lang) string {
+	return levelLabels.get(l.String(), lang)
+}
+
+// MarshalText encodes level to text as numeric ID (same as JSON)
+func (l LevelID) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(int(l))), nil
+}
+
+// UnmarshalText decodes level from text (case-insensitive name or numeric ID)
+func (l *LevelID) UnmarshalText(data []byte) error {
+	value := string(data)
+
+	switch {
+	case strings.EqualFold(value, LEVEL_MINOR):
+		*l = LEVEL_ID_MINOR
+		return nil
+	case strings.EqualFold(value, LEVEL_UNAVAILABLE):
+		*l = LEVEL_ID_UNAVAILABLE
+		return nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 8)
+
+	if err != nil {
+		return fmt.Errorf("Unknown level %q", value)
+	}
+
+	*l = LevelID(id)
+
+	return nil
+}
+
+// MarshalJSON encodes level to JSON as number (same as API)
+func (l LevelID) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(l))), nil
+}
+
+// UnmarshalJSON decodes level from JSON number
+func (l *LevelID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*l = 0
+		return nil
+	}
+
+	id, err := strconv.ParseUint(string(data), 10, 8)
+
+	if err != nil {
+		return fmt.Errorf("Can't decode level ID: %w", err)
+	}
+
+	*l = LevelID(id)
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// get returns localized label for given value
+func (l labels) get(value string, lang Lang) string {
+	names := l[value]
+
+	switch {
+	case names == nil:
+		return value
+	case names[lang] != "":
+		return names[lang]
+	}
+
+	return names[LANG_EN]
+}
+
+// foldValue returns known value which is equal to given value ignoring case,
+// unknown values are returned as is
+func foldValue[T ~string](value string, known []T) T {
+	for _, v := range known {
+		if strings.EqualFold(value, string(v)) {
+			return v
+		}
+	}
+
+	return T(value)
+}
@@ -0,0 +1,383 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ExportColumn is column of exported data
+type ExportColumn string
+
+const (
+	COLUMN_ID         ExportColumn = "id"
+	COLUMN_TITLE      ExportColumn = "title"
+	COLUMN_STATUS     ExportColumn = "status"
+	COLUMN_LEVEL      ExportColumn = "level"
+	COLUMN_START      ExportColumn = "start"
+	COLUMN_END        ExportColumn = "end"
+	COLUMN_DURATION   ExportColumn = "duration"
+	COLUMN_REGIONS    ExportColumn = "regions"
+	COLUMN_ZONES      ExportColumn = "zones"
+	COLUMN_SERVICES   ExportColumn = "services"
+	COLUMN_REPORT_URL ExportColumn = "report_url"
+)
+
+// AllExportColumns is a slice with all export columns
+var AllExportColumns = []ExportColumn{
+	COLUMN_ID, COLUMN_TITLE, COLUMN_STATUS, COLUMN_LEVEL, COLUMN_START,
+	COLUMN_END, COLUMN_DURATION, COLUMN_REGIONS, COLUMN_ZONES,
+	COLUMN_SERVICES, COLUMN_REPORT_URL,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ExportOptions contains options for CSV and JSONL export
+type ExportOptions struct {
+	Columns []ExportColumn // Columns to export (all by default)
+	Lang    Lang           // Language of report URLs
+
+	// Exploded enables exploded mode with one row per (incident, service, zone).
+	// In this mode regions, zones and services columns contain single value.
+	Exploded bool
+
+	Delimiter     rune   // CSV fields delimiter (',' by default)
+	ListSeparator string // CSV separator for list values ('|' by default)
+	NoHeader      bool   // Don't write CSV header
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// exportRow is exported row
+type exportRow struct {
+	incident *Incident
+	regions  []string
+	zones    []string
+	services []string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CSV writes incidents to given writer in CSV format. Dates are formatted
+// as RFC 3339 in UTC and duration is in seconds. End date and duration of
+// open incidents are empty.
+func (i Incidents) CSV(w io.Writer, opts ExportOptions) error {
+	opts, err := opts.withDefaults()
+
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = opts.Delimiter
+
+	if !opts.NoHeader {
+		header := make([]string, len(opts.Columns))
+
+		for idx, c := range opts.Columns {
+			header[idx] = string(c)
+		}
+
+		err = cw.Write(header)
+
+		if err != nil {
+			return fmt.Errorf("Can't write CSV header: %w", err)
+		}
+	}
+
+	record := make([]string, len(opts.Columns))
+
+	for _, r := range i.exportRows(opts.Exploded) {
+		for idx, c := range opts.Columns {
+			record[idx] = r.csvValue(c, opts)
+		}
+
+		err = cw.Write(record)
+
+		if err != nil {
+			return fmt.Errorf("Can't write CSV record: %w", err)
+		}
+	}
+
+	cw.Flush()
+
+	if cw.Error() != nil {
+		return fmt.Errorf("Can't write CSV data: %w", cw.Error())
+	}
+
+	return nil
+}
+
+// JSONL writes incidents to given writer in JSON Lines format. Dates are
+// formatted as RFC 3339 in UTC and duration is in seconds. End date and
+// duration of open incidents are null. In regular mode regions, zones and
+// services are arrays.
+func (i Incidents) JSONL(w io.Writer, opts ExportOptions) error {
+	opts, err := opts.withDefaults()
+
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	var buf bytes.Buffer
+
+	for _, r := range i.exportRows(opts.Exploded) {
+		buf.Reset()
+		buf.WriteByte('{')
+
+		for idx, c := range opts.Columns {
+			if idx > 0 {
+				buf.WriteByte(',')
+			}
+
+			value, err := json.Marshal(r.jsonValue(c, opts))
+
+			if err != nil {
+				return fmt.Errorf("Can't encode %q column of incident %d: %w", c, r.incident.ID, err)
+			}
+
+			buf.WriteString(strconv.Quote(string(c)))
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+
+		buf.WriteString("}\n")
+
+		_, err = bw.Write(buf.Bytes())
+
+		if err != nil {
+			return fmt.Errorf("Can't write JSONL data: %w", err)
+		}
+	}
+
+	err = bw.Flush()
+
+	if err != nil {
+		return fmt.Errorf("Can't write JSONL data: %w", err)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsValid returns true if column is known
+func (c ExportColumn) IsValid() bool {
+	return slices.Contains(AllExportColumns, c)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// withDefaults validates options and returns options with default values
+func (o ExportOptions) withDefaults() (ExportOptions, error) {
+	o.Lang = Lang(o.Lang.orDefault())
+
+	if len(o.Columns) == 0 {
+		o.Columns = AllExportColumns
+	}
+
+	for _, c := range o.Columns {
+		if !c.IsValid() {
+			return o, fmt.Errorf("Unknown export column %q", c)
+		}
+	}
+
+	if o.Delimiter == 0 {
+		o.Delimiter = ','
+	}
+
+	if o.ListSeparator == "" {
+		o.ListSeparator = "|"
+	}
+
+	return o, nil
+}
+
+// exportRows returns rows for export
+func (i Incidents) exportRows(exploded bool) []*exportRow {
+	var result []*exportRow
+
+	for _, ii := range i {
+		if ii == nil {
+			continue
+		}
+
+		if !exploded {
+			result = append(result, &exportRow{
+				incident: ii,
+				regions:  uniqueStrings(ii.RegionList()),
+				zones:    uniqueStrings(ii.ZoneList()),
+				services: uniqueStrings(ii.ServiceList()),
+			})
+
+			continue
+		}
+
+		result = append(result, ii.explodedRows()...)
+	}
+
+	return result
+}
+
+// explodedRows returns one row per every affected service and zone. If incident
+// has no services or zones, corresponding values are empty.
+func (i *Incident) explodedRows() []*exportRow {
+	type location struct{ region, zone string }
+
+	var locations []location
+
+	for _, r := range i.Regions {
+		if r == nil {
+			continue
+		}
+
+		if len(r.Zones) == 0 {
+			locations = append(locations, location{string(r.Code), ""})
+			continue
+		}
+
+		for _, z := range r.Zones {
+			if z != nil {
+				locations = append(locations, location{string(r.Code), z.ID})
+			}
+		}
+	}
+
+	if len(locations) == 0 {
+		locations = []location{{}}
+	}
+
+	services := uniqueStrings(i.ServiceList())
+
+	if len(services) == 0 {
+		services = []string{""}
+	}
+
+	var result []*exportRow
+
+	for _, s := range services {
+		for _, l := range locations {
+			result = append(result, &exportRow{
+				incident: i,
+				regions:  singleValue(l.region),
+				zones:    singleValue(l.zone),
+				services: singleValue(s),
+			})
+		}
+	}
+
+	return result
+}
+
+// csvValue returns value of given column for CSV
+func (r *exportRow) csvValue(c ExportColumn, opts ExportOptions) string {
+	switch v := r.value(c, opts).(type) {
+	case string:
+		return v
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case []string:
+		return strings.Join(v, opts.ListSeparator)
+	}
+
+	return ""
+}
+
+// jsonValue returns value of given column for JSON
+func (r *exportRow) jsonValue(c ExportColumn, opts ExportOptions) any {
+	v := r.value(c, opts)
+
+	if opts.Exploded {
+		if list, ok := v.([]string); ok {
+			if len(list) == 0 {
+				return nil
+			}
+
+			return list[0]
+		}
+	}
+
+	if list, ok := v.([]string); ok && list == nil {
+		return []string{}
+	}
+
+	return v
+}
+
+// value returns raw value of given column
+func (r *exportRow) value(c ExportColumn, opts ExportOptions) any {
+	i := r.incident
+
+	switch c {
+	case COLUMN_ID:
+		return i.ID
+	case COLUMN_TITLE:
+		return i.Title
+	case COLUMN_STATUS:
+		return string(i.Status)
+	case COLUMN_LEVEL:
+		return i.LevelID.String()
+	case COLUMN_START:
+		return formatExportDate(i.StartDate)
+	case COLUMN_END:
+		return formatExportDate(i.EndDate)
+	case COLUMN_DURATION:
+		if i.EndDate.IsZero() {
+			return nil
+		}
+
+		return int64(i.Duration() / time.Second)
+	case COLUMN_REGIONS:
+		return r.regions
+	case COLUMN_ZONES:
+		return r.zones
+	case COLUMN_SERVICES:
+		return r.services
+	case COLUMN_REPORT_URL:
+		if i.IsReportPublished {
+			return i.URL(opts.Lang)
+		}
+
+		return ""
+	}
+
+	return nil
+}
+
+// formatExportDate formats date for export
+func formatExportDate(d Date) any {
+	if d.IsZero() {
+		return nil
+	}
+
+	return d.UTC().Format(time.RFC3339)
+}
+
+// singleValue returns slice with given value or nil if value is empty
+func singleValue(v string) []string {
+	if v == "" {
+		return nil
+	}
+
+	return []string{v}
+}
@@ -0,0 +1,517 @@
+package exporter
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/essentialkaos/ycs"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CONTENT_TYPE is content type of Prometheus text exposition format
+const CONTENT_TYPE = "text/plain; version=0.0.4; charset=utf-8"
+
+const (
+	ENDPOINT_SERVICES  = "services"
+	ENDPOINT_INCIDENTS = "incidents"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Config contains exporter configuration
+type Config struct {
+	// Interval is refresh interval (1 minute by default)
+	Interval time.Duration
+
+	// Request is incidents request. Only incidents returned by this request
+	// are used for metrics.
+	Request ycs.IncidentsRequest
+}
+
+// Exporter periodically fetches services and incidents and exposes them as
+// Prometheus metrics
+type Exporter struct {
+	client *ycs.Client
+	cfg    Config
+
+	services  ycs.Services
+	incidents ycs.Incidents
+	api       map[string]*apiStatus
+	refreshed time.Time
+
+	mu sync.RWMutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// apiStatus contains info about the last API request
+type apiStatus struct {
+	up       bool
+	duration time.Duration
+}
+
+// metric is metric family
+type metric struct {
+	name   string
+	help   string
+	series []*series
+}
+
+// series is metric time series
+type series struct {
+	labels string
+	value  float64
+}
+
+// location is region and zone affected by incident
+type location struct {
+	region string
+	zone   string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// labelReplacer escapes label values
+var labelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// New creates new exporter. If client is nil, default client is used.
+func New(client *ycs.Client, cfg Config) *Exporter {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Minute
+	}
+
+	return &Exporter{
+		client: client,
+		cfg:    cfg,
+		api:    map[string]*apiStatus{},
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Run refreshes data until context is cancelled. Refresh errors are exposed
+// using ycs_api_up metric, so they don't stop the loop.
+func (e *Exporter) Run(ctx context.Context) error {
+	if e == nil {
+		return fmt.Errorf("Exporter is nil")
+	}
+
+	ticker := time.NewTicker(e.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		e.Refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh fetches services and incidents. If request fails, data from the
+// previous successful request is kept. If context is cancelled, results of
+// already completed requests are kept.
+func (e *Exporter) Refresh(ctx context.Context) error {
+	if e == nil {
+		return fmt.Errorf("Exporter is nil")
+	}
+
+	start := time.Now()
+	services, errServices := e.getServices(ctx)
+	servicesStatus := newAPIStatus(ctx, errServices, time.Since(start))
+
+	start = time.Now()
+	incidents, errIncidents := e.getIncidents(ctx)
+	incidentsStatus := newAPIStatus(ctx, errIncidents, time.Since(start))
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if servicesStatus != nil {
+		e.api[ENDPOINT_SERVICES] = servicesStatus
+		e.refreshed = time.Now()
+	}
+
+	if incidentsStatus != nil {
+		e.api[ENDPOINT_INCIDENTS] = incidentsStatus
+		e.refreshed = time.Now()
+	}
+
+	if errServices == nil {
+		e.services = services
+	}
+
+	if errIncidents == nil {
+		e.incidents = incidents
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return errors.Join(errServices, errIncidents)
+}
+
+// ServeHTTP writes metrics in Prometheus text format
+func (e *Exporter) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		rw.Header().Set("Allow", "GET, HEAD")
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var buf bytes.Buffer
+
+	err := e.Write(&buf)
+
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", CONTENT_TYPE)
+	rw.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	rw.WriteHeader(http.StatusOK)
+
+	if r.Method != http.MethodHead {
+		rw.Write(buf.Bytes())
+	}
+}
+
+// Write writes metrics in Prometheus text format to given writer
+func (e *Exporter) Write(w io.Writer) error {
+	if e == nil {
+		return fmt.Errorf("Exporter is nil")
+	}
+
+	e.mu.RLock()
+	metrics := e.collect(time.Now())
+	e.mu.RUnlock()
+
+	var buf bytes.Buffer
+
+	for _, m := range metrics {
+		fmt.Fprintf(&buf, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(&buf, "# TYPE %s gauge\n", m.name)
+
+		slices.SortFunc(m.series, func(a, b *series) int {
+			return strings.Compare(a.labels, b.labels)
+		})
+
+		for _, s := range m.series {
+			buf.WriteString(m.name)
+
+			if s.labels != "" {
+				buf.WriteString("{" + s.labels + "}")
+			}
+
+			buf.WriteString(" " + strconv.FormatFloat(s.value, 'g', -1, 64) + "\n")
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getServices fetches services
+func (e *Exporter) getServices(ctx context.Context) (ycs.Services, error) {
+	if e.client == nil {
+		return ycs.GetServicesContext(ctx, e.cfg.Request.Lang)
+	}
+
+	return e.client.GetServicesContext(ctx, e.cfg.Request.Lang)
+}
+
+// getIncidents fetches incidents
+func (e *Exporter) getIncidents(ctx context.Context) (ycs.Incidents, error) {
+	if e.client == nil {
+		return ycs.GetIncidentsContext(ctx, e.cfg.Request)
+	}
+
+	return e.client.GetIncidentsContext(ctx, e.cfg.Request)
+}
+
+// newAPIStatus returns status of API request or nil if request was
+// interrupted by context cancellation
+func newAPIStatus(ctx context.Context, err error, dur time.Duration) *apiStatus {
+	if err != nil && ctx.Err() != nil {
+		return nil
+	}
+
+	return &apiStatus{err == nil, dur}
+}
+
+// collect collects all metrics
+func (e *Exporter) collect(now time.Time) []*metric {
+	return []*metric{
+		e.collectAPIUp(),
+		e.collectAPIDuration(),
+		e.collectLastRefresh(),
+		e.collectIncidentOpen(),
+		e.collectServiceOpen(),
+		e.collectIncidentsTotal(),
+		e.collectIncidentDuration(now),
+	}
+}
+
+// collectAPIUp collects ycs_api_up metric
+func (e *Exporter) collectAPIUp() *metric {
+	m := &metric{
+		name: "ycs_api_up",
+		help: "Whether the last request to status API was successful",
+	}
+
+	for _, endpoint := range []string{ENDPOINT_INCIDENTS, ENDPOINT_SERVICES} {
+		if s := e.api[endpoint]; s != nil {
+			m.add(boolValue(s.up), "endpoint", endpoint)
+		}
+	}
+
+	return m
+}
+
+// collectAPIDuration collects ycs_api_request_duration_seconds metric
+func (e *Exporter) collectAPIDuration() *metric {
+	m := &metric{
+		name: "ycs_api_request_duration_seconds",
+		help: "Duration of the last request to status API",
+	}
+
+	for _, endpoint := range []string{ENDPOINT_INCIDENTS, ENDPOINT_SERVICES} {
+		if s := e.api[endpoint]; s != nil {
+			m.add(s.duration.Seconds(), "endpoint", endpoint)
+		}
+	}
+
+	return m
+}
+
+// collectLastRefresh collects ycs_last_refresh_timestamp_seconds metric
+func (e *Exporter) collectLastRefresh() *metric {
+	m := &metric{
+		name: "ycs_last_refresh_timestamp_seconds",
+		help: "Unix time of the last data refresh",
+	}
+
+	if !e.refreshed.IsZero() {
+		m.add(float64(e.refreshed.UnixMilli()) / 1000)
+	}
+
+	return m
+}
+
+// collectIncidentOpen collects ycs_service_incident_open metric
+func (e *Exporter) collectIncidentOpen() *metric {
+	m := &metric{
+		name: "ycs_service_incident_open",
+		help: "Number of open incidents affecting service in zone",
+	}
+
+	counters := map[string]float64{}
+
+	for _, i := range e.incidents {
+		if i == nil || i.Status != ycs.STATUS_OPEN {
+			continue
+		}
+
+		services := i.ServiceList()
+
+		if len(services) == 0 {
+			services = []string{""}
+		}
+
+		for _, s := range services {
+			for _, l := range incidentLocations(i) {
+				counters[formatLabels(
+					"service", s, "region", l.region,
+					"zone", l.zone, "level", i.LevelID.String(),
+				)]++
+			}
+		}
+	}
+
+	for labels, value := range counters {
+		m.series = append(m.series, &series{labels, value})
+	}
+
+	return m
+}
+
+// collectServiceOpen collects ycs_service_open_incidents metric
+func (e *Exporter) collectServiceOpen() *metric {
+	m := &metric{
+		name: "ycs_service_open_incidents",
+		help: "Number of open incidents affecting service in region",
+	}
+
+	for _, s := range e.services {
+		if s == nil {
+			continue
+		}
+
+		var count float64
+
+		for _, i := range e.incidents {
+			if i != nil && i.Status == ycs.STATUS_OPEN && affects(i, s) {
+				count++
+			}
+		}
+
+		m.add(count, "service", s.Name, "region", string(s.InstallationCode))
+	}
+
+	return m
+}
+
+// collectIncidentsTotal collects ycs_incidents_total metric
+func (e *Exporter) collectIncidentsTotal() *metric {
+	m := &metric{
+		name: "ycs_incidents_total",
+		help: "Number of incidents returned by status API",
+	}
+
+	counters := map[string]float64{}
+
+	for _, status := range []ycs.Status{ycs.STATUS_OPEN, ycs.STATUS_RESOLVED} {
+		for _, level := range ycs.AllLevels {
+			counters[formatLabels("status", string(status), "level", level.String())] = 0
+		}
+	}
+
+	for _, i := range e.incidents {
+		if i != nil {
+			counters[formatLabels("status", string(i.Status), "level", i.LevelID.String())]++
+		}
+	}
+
+	for labels, value := range counters {
+		m.series = append(m.series, &series{labels, value})
+	}
+
+	return m
+}
+
+// collectIncidentDuration collects ycs_incident_duration_seconds metric
+func (e *Exporter) collectIncidentDuration(now time.Time) *metric {
+	m := &metric{
+		name: "ycs_incident_duration_seconds",
+		help: "Incident duration (time since start for incidents without end date)",
+	}
+
+	for _, i := range e.incidents {
+		if i == nil || i.StartDate.IsZero() {
+			continue
+		}
+
+		end := i.EndDate.Time
+
+		if end.IsZero() {
+			end = now
+		}
+
+		m.add(
+			max(end.Sub(i.StartDate.Time), 0).Seconds(),
+			"id", strconv.FormatUint(uint64(i.ID), 10),
+			"status", string(i.Status),
+			"level", i.LevelID.String(),
+		)
+	}
+
+	return m
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// add adds series with given value and label pairs
+func (m *metric) add(value float64, labels ...string) {
+	m.series = append(m.series, &series{formatLabels(labels...), value})
+}
+
+// incidentLocations returns regions and zones affected by incident. If region
+// has no zones, zone is empty.
+func incidentLocations(i *ycs.Incident) []location {
+	var result []location
+
+	for _, r := range i.Regions {
+		if r == nil {
+			continue
+		}
+
+		if len(r.Zones) == 0 {
+			result = append(result, location{string(r.Code), ""})
+			continue
+		}
+
+		for _, z := range r.Zones {
+			if z != nil && !slices.Contains(result, location{string(r.Code), z.ID}) {
+				result = append(result, location{string(r.Code), z.ID})
+			}
+		}
+	}
+
+	if len(result) == 0 {
+		return []location{{}}
+	}
+
+	return result
+}
+
+// affects returns true if incident affects given service. Incidents without
+// regions affect service in all regions.
+func affects(i *ycs.Incident, s *ycs.Service) bool {
+	if !slices.Contains(i.ServiceList(), s.Name) {
+		return false
+	}
+
+	regions := i.RegionList()
+
+	return len(regions) == 0 || slices.Contains(regions, string(s.InstallationCode))
+}
+
+// formatLabels formats label pairs
+func formatLabels(pairs ...string) string {
+	var buf strings.Builder
+
+	for idx := 0; idx+1 < len(pairs); idx += 2 {
+		if idx > 0 {
+			buf.WriteByte(',')
+		}
+
+		buf.WriteString(pairs[idx] + `="` + labelReplacer.Replace(pairs[idx+1]) + `"`)
+	}
+
+	return buf.String()
+}
+
+// boolValue converts boolean to metric value
+func boolValue(v bool) float64 {
+	if v {
+		return 1
+	}
+
+	return 0
+}
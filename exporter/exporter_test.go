@@ -0,0 +1,264 @@
+package exporter
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/essentialkaos/check"
+
+	"github.com/essentialkaos/ycs"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type ExporterSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&ExporterSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *ExporterSuite) TestExporter(c *C) {
+	server, failIncidents := newAPIStub(c)
+	defer server.Close()
+
+	client := ycs.NewClient()
+	client.SetURL(server.URL)
+	client.SetRetryPolicy(ycs.RetryPolicy{})
+
+	e := New(client, Config{})
+
+	c.Assert(e.cfg.Interval, Equals, time.Minute)
+
+	metrics := render(c, e)
+
+	c.Assert(metrics, Not(Matches), `(?s).*ycs_api_up\{.*`)
+	c.Assert(metrics, Matches, `(?s).*# TYPE ycs_incidents_total gauge\n`+
+		`ycs_incidents_total\{status="open",level="Minor"\} 0\n.*`)
+
+	c.Assert(e.Refresh(context.Background()), IsNil)
+
+	metrics = render(c, e)
+
+	for _, line := range []string{
+		`ycs_api_up{endpoint="incidents"} 1`,
+		`ycs_api_up{endpoint="services"} 1`,
+		`ycs_service_incident_open{service="Compute Cloud",region="ru",zone="ru-central1-a",level="Minor"} 1`,
+		`ycs_service_incident_open{service="Compute Cloud",region="ru",zone="ru-central1-b",level="Minor"} 1`,
+		`ycs_service_incident_open{service="Virtual Private Cloud",region="ru",zone="ru-central1-a",level="Minor"} 1`,
+		`ycs_service_incident_open{service="Virtual Private Cloud",region="ru",zone="ru-central1-b",level="Minor"} 1`,
+		`ycs_service_open_incidents{service="Compute Cloud",region="ru"} 1`,
+		`ycs_service_open_incidents{service="Compute Cloud",region="kz"} 0`,
+		`ycs_service_open_incidents{service="API Gateway",region="ru"} 0`,
+		`ycs_incidents_total{status="open",level="Minor"} 1`,
+		`ycs_incidents_total{status="open",level="Unavailable"} 0`,
+		`ycs_incident_duration_seconds{id="972",status="resolved",level="Unavailable"} 25320`,
+		`ycs_incident_duration_seconds{id="1014",status="open",level="Minor"} 8700`,
+	} {
+		c.Assert(strings.Contains(metrics, "\n"+line+"\n"), Equals, true, Commentf("Line %q not found", line))
+	}
+
+	c.Assert(strings.Count(metrics, "\nycs_service_incident_open{"), Equals, 4)
+	c.Assert(strings.Count(metrics, "\nycs_incident_duration_seconds{"), Equals, 20)
+	c.Assert(metrics, Matches, `(?s).*\nycs_last_refresh_timestamp_seconds [0-9.e+]+\n.*`)
+	c.Assert(metrics, Matches, `(?s).*\nycs_api_request_duration_seconds\{endpoint="services"\} [0-9.e-]+\n.*`)
+
+	failIncidents.Store(true)
+
+	c.Assert(e.Refresh(context.Background()), NotNil)
+
+	metrics = render(c, e)
+
+	c.Assert(strings.Contains(metrics, "\nycs_api_up{endpoint=\"incidents\"} 0\n"), Equals, true)
+	c.Assert(strings.Contains(metrics, "\nycs_api_up{endpoint=\"services\"} 1\n"), Equals, true)
+	c.Assert(strings.Count(metrics, "\nycs_service_incident_open{"), Equals, 4)
+}
+
+func (s *ExporterSuite) TestHandler(c *C) {
+	e := New(nil, Config{})
+	e.incidents = ycs.Incidents{
+		{
+			ID: 1, Status: ycs.STATUS_OPEN, LevelID: ycs.LEVEL_ID_UNAVAILABLE,
+			StartDate: ycs.Date{Time: time.Now().Add(-time.Hour)},
+			Services:  ycs.Services{{Name: `Test "quoted" \ service`}},
+		},
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	c.Assert(rec.Code, Equals, http.StatusOK)
+	c.Assert(rec.Header().Get("Content-Type"), Equals, CONTENT_TYPE)
+	c.Assert(rec.Body.String(), Matches, `(?s).*\nycs_service_incident_open\{service="Test \\"quoted\\" \\\\ service",region="",zone="",level="Unavailable"\} 1\n.*`)
+	c.Assert(rec.Body.String(), Matches, `(?s).*\nycs_incident_duration_seconds\{id="1",status="open",level="Unavailable"\} 36[0-9.]+\n.*`)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/metrics", nil))
+
+	c.Assert(rec.Code, Equals, http.StatusOK)
+	c.Assert(rec.Body.Len(), Equals, 0)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+
+	c.Assert(rec.Code, Equals, http.StatusMethodNotAllowed)
+
+	var nilExporter *Exporter
+
+	rec = httptest.NewRecorder()
+	nilExporter.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	c.Assert(rec.Code, Equals, http.StatusInternalServerError)
+	c.Assert(nilExporter.Refresh(context.Background()), NotNil)
+	c.Assert(nilExporter.Run(context.Background()), NotNil)
+	c.Assert(nilExporter.Write(io.Discard), NotNil)
+}
+
+func (s *ExporterSuite) TestRun(c *C) {
+	server, _ := newAPIStub(c)
+	defer server.Close()
+
+	client := ycs.NewClient()
+	client.SetURL(server.URL)
+
+	e := New(client, Config{Interval: time.Hour})
+
+	c.Assert(e.Refresh(context.Background()), IsNil)
+	c.Assert(strings.Contains(render(c, e), "\nycs_api_up{endpoint=\"incidents\"} 1\n"), Equals, true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c.Assert(e.Run(ctx), Equals, context.Canceled)
+	c.Assert(e.Refresh(ctx), Equals, context.Canceled)
+	c.Assert(strings.Contains(render(c, e), "\nycs_api_up{endpoint=\"incidents\"} 1\n"), Equals, true)
+}
+
+func (s *ExporterSuite) TestRunInterval(c *C) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	services, err := os.ReadFile("../testdata/services.json")
+	c.Assert(err, IsNil)
+
+	incidents, err := os.ReadFile("../testdata/incidents.json")
+	c.Assert(err, IsNil)
+
+	var servicesRequests, incidentsRequests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services":
+			// The third refresh means that two refreshes are done
+			if servicesRequests.Add(1) == 3 {
+				cancel()
+				<-r.Context().Done()
+				return
+			}
+
+			rw.Write(services)
+
+		case "/incidents":
+			incidentsRequests.Add(1)
+			rw.Write(incidents)
+		}
+	}))
+
+	defer server.Close()
+
+	client := ycs.NewClient()
+	client.SetURL(server.URL)
+	client.SetRetryPolicy(ycs.RetryPolicy{})
+
+	e := New(client, Config{Interval: 10 * time.Millisecond})
+
+	c.Assert(e.Run(ctx), Equals, context.Canceled)
+	c.Assert(servicesRequests.Load(), Equals, int32(3))
+	c.Assert(incidentsRequests.Load(), Equals, int32(2))
+	c.Assert(strings.Contains(render(c, e), "\nycs_api_up{endpoint=\"incidents\"} 1\n"), Equals, true)
+}
+
+func (s *ExporterSuite) TestRefreshCancel(c *C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/incidents" {
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+
+		data, _ := os.ReadFile("../testdata/services.json")
+		rw.Write(data)
+	}))
+
+	defer server.Close()
+
+	client := ycs.NewClient()
+	client.SetURL(server.URL)
+	client.SetRetryPolicy(ycs.RetryPolicy{})
+
+	e := New(client, Config{})
+
+	c.Assert(e.Refresh(ctx), Equals, context.Canceled)
+
+	metrics := render(c, e)
+
+	c.Assert(strings.Contains(metrics, "\nycs_api_up{endpoint=\"services\"} 1\n"), Equals, true)
+	c.Assert(metrics, Not(Matches), `(?s).*ycs_api_up\{endpoint="incidents"\}.*`)
+	c.Assert(e.services, Not(HasLen), 0)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newAPIStub starts status API stand-in serving test data
+func newAPIStub(c *C) (*httptest.Server, *atomic.Bool) {
+	services, err := os.ReadFile("../testdata/services.json")
+	c.Assert(err, IsNil)
+
+	incidents, err := os.ReadFile("../testdata/incidents.json")
+	c.Assert(err, IsNil)
+
+	failIncidents := &atomic.Bool{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/services":
+			rw.Write(services)
+		case r.URL.Path == "/incidents" && !failIncidents.Load():
+			rw.Write(incidents)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return server, failIncidents
+}
+
+// render renders metrics
+func render(c *C, e *Exporter) string {
+	var buf bytes.Buffer
+
+	c.Assert(e.Write(&buf), IsNil)
+
+	return buf.String()
+}
@@ -0,0 +1,352 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// FeedOptions contains options for RSS and Atom feeds
+type FeedOptions struct {
+	Title       string // Feed title
+	Description string // Feed description
+	Link        string // Link to website (status page by default)
+	SelfURL     string // URL of the feed itself
+	Lang        Lang   // Language of links and labels
+
+	Regions  []RegionCode // Include only incidents affecting given regions
+	Zones    []string     // Include only incidents affecting given zones
+	Services []string     // Include only incidents affecting given services (name or slug)
+
+	PerComment bool // Create entry for every comment instead of every incident
+	Limit      int  // Maximum number of entries (0 = no limit)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// feedEntry is feed entry
+type feedEntry struct {
+	ID         string
+	Title      string
+	Link       string
+	Content    string
+	Published  time.Time
+	Updated    time.Time
+	Categories []string
+}
+
+// rssFeed is RSS 2.0 document
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+// rssChannel is RSS channel
+type rssChannel struct {
+	Title         string     `xml:"title"`
+	SelfLink      *atomLink  `xml:"atom:link,omitempty"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	Language      string     `xml:"language"`
+	LastBuildDate string     `xml:"lastBuildDate,omitempty"`
+	Items         []*rssItem `xml:"item"`
+}
+
+// rssItem is RSS item
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Description string   `xml:"description,omitempty"`
+	Categories  []string `xml:"category"`
+}
+
+// rssGUID is RSS item GUID
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// atomFeed is Atom document
+type atomFeed struct {
+	XMLName  xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	Lang     string       `xml:"xml:lang,attr"`
+	ID       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Subtitle string       `xml:"subtitle,omitempty"`
+	Updated  string       `xml:"updated"`
+	Author   atomAuthor   `xml:"author"`
+	Links    []*atomLink  `xml:"link"`
+	Entries  []*atomEntry `xml:"entry"`
+}
+
+// atomAuthor is Atom author
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// atomLink is Atom link
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// atomEntry is Atom entry
+type atomEntry struct {
+	ID         string          `xml:"id"`
+	Title      string          `xml:"title"`
+	Updated    string          `xml:"updated"`
+	Published  string          `xml:"published,omitempty"`
+	Link       *atomLink       `xml:"link"`
+	Categories []*atomCategory `xml:"category"`
+	Content    *atomContent    `xml:"content,omitempty"`
+}
+
+// atomCategory is Atom category
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// atomContent is Atom entry content
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// RSS generates RSS 2.0 feed with incidents
+func (i Incidents) RSS(opts FeedOptions) ([]byte, error) {
+	opts = opts.withDefaults()
+	entries := i.feedEntries(opts)
+
+	channel := rssChannel{
+		Title:       opts.Title,
+		Link:        opts.Link,
+		Description: opts.Description,
+		Language:    string(opts.Lang),
+	}
+
+	if len(entries) != 0 {
+		channel.LastBuildDate = entries[0].Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	if opts.SelfURL != "" {
+		channel.SelfLink = &atomLink{Href: opts.SelfURL, Rel: "self", Type: "application/rss+xml"}
+	}
+
+	for _, e := range entries {
+		channel.Items = append(channel.Items, &rssItem{
+			Title:       e.Title,
+			Link:        e.Link,
+			GUID:        rssGUID{Value: e.ID, IsPermaLink: e.ID == e.Link},
+			PubDate:     e.Updated.UTC().Format(time.RFC1123Z),
+			Description: e.Content,
+			Categories:  e.Categories,
+		})
+	}
+
+	return encodeFeed(&rssFeed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: channel,
+	})
+}
+
+// Atom generates Atom feed with incidents
+func (i Incidents) Atom(opts FeedOptions) ([]byte, error) {
+	opts = opts.withDefaults()
+	entries := i.feedEntries(opts)
+
+	feed := &atomFeed{
+		Lang:     string(opts.Lang),
+		ID:       opts.Link,
+		Title:    opts.Title,
+		Subtitle: opts.Description,
+		Updated:  time.Now().UTC().Format(time.RFC3339),
+		Author:   atomAuthor{Name: "Yandex Cloud"},
+		Links:    []*atomLink{{Href: opts.Link, Rel: "alternate", Type: "text/html"}},
+	}
+
+	if opts.SelfURL != "" {
+		feed.ID = opts.SelfURL
+		feed.Links = append(feed.Links, &atomLink{
+			Href: opts.SelfURL, Rel: "self", Type: "application/atom+xml",
+		})
+	}
+
+	if len(entries) != 0 {
+		feed.Updated = entries[0].Updated.UTC().Format(time.RFC3339)
+	}
+
+	for _, e := range entries {
+		entry := &atomEntry{
+			ID:      e.ID,
+			Title:   e.Title,
+			Updated: e.Updated.UTC().Format(time.RFC3339),
+			Link:    &atomLink{Href: e.Link, Rel: "alternate", Type: "text/html"},
+		}
+
+		if !e.Published.IsZero() {
+			entry.Published = e.Published.UTC().Format(time.RFC3339)
+		}
+
+		for _, c := range e.Categories {
+			entry.Categories = append(entry.Categories, &atomCategory{Term: c})
+		}
+
+		if e.Content != "" {
+			entry.Content = &atomContent{Type: "html", Value: e.Content}
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return encodeFeed(feed)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// withDefaults returns options with default values
+func (o FeedOptions) withDefaults() FeedOptions {
+	o.Lang = Lang(o.Lang.orDefault())
+
+	if o.Title == "" {
+		o.Title = "Yandex Cloud incidents"
+	}
+
+	if o.Description == "" {
+		o.Description = "Incidents on Yandex Cloud status page"
+	}
+
+	if o.Link == "" {
+		o.Link = fmt.Sprintf("https://status.yandex.cloud/%s", o.Lang)
+	}
+
+	return o
+}
+
+// feedEntries returns sorted feed entries for given options
+func (i Incidents) feedEntries(opts FeedOptions) []*feedEntry {
+	var filters []IncidentFilter
+
+	if len(opts.Regions) != 0 {
+		filters = append(filters, ByRegion(opts.Regions...))
+	}
+
+	if len(opts.Zones) != 0 {
+		filters = append(filters, ByZone(opts.Zones...))
+	}
+
+	if len(opts.Services) != 0 {
+		filters = append(filters, ByService(opts.Services...))
+	}
+
+	var entries []*feedEntry
+
+	for _, ii := range i.Filter(filters...) {
+		if opts.PerComment {
+			entries = append(entries, ii.commentEntries(opts.Lang)...)
+		} else {
+			entries = append(entries, ii.feedEntry(opts.Lang))
+		}
+	}
+
+	slices.SortStableFunc(entries, func(a, b *feedEntry) int {
+		return b.Updated.Compare(a.Updated)
+	})
+
+	if opts.Limit > 0 && len(entries) > opts.Limit {
+		entries = entries[:opts.Limit]
+	}
+
+	return entries
+}
+
+// feedEntry creates feed entry for incident
+func (i *Incident) feedEntry(lang Lang) *feedEntry {
+	e := &feedEntry{
+		ID:         i.URL(lang),
+		Title:      fmt.Sprintf("[%s] %s", i.Status.Label(lang), i.Title),
+		Link:       i.URL(lang),
+		Published:  firstDate(i.CreatedAt, i.StartDate),
+		Updated:    firstDate(i.UpdatedAt, i.CreatedAt, i.StartDate),
+		Categories: uniqueStrings(i.ServiceList()),
+	}
+
+	switch {
+	case i.IsReportPublished && i.Report != "":
+		e.Content = i.Report
+	case i.Comments.Latest() != nil:
+		e.Content = i.Comments.Latest().Content
+	}
+
+	return e
+}
+
+// commentEntries creates feed entries for all incident comments
+func (i *Incident) commentEntries(lang Lang) []*feedEntry {
+	var result []*feedEntry
+
+	categories := uniqueStrings(i.ServiceList())
+
+	for _, c := range i.Comments {
+		if c == nil {
+			continue
+		}
+
+		title := i.Title
+
+		if c.Type != "" {
+			title = fmt.Sprintf("[%s] %s", c.Type.Label(lang), i.Title)
+		}
+
+		result = append(result, &feedEntry{
+			ID:         fmt.Sprintf("%s#comment-%d", i.URL(lang), c.ID),
+			Title:      title,
+			Link:       i.URL(lang),
+			Content:    c.Content,
+			Published:  c.CreatedAt.Time,
+			Updated:    firstDate(c.UpdatedAt, c.CreatedAt),
+			Categories: categories,
+		})
+	}
+
+	return result
+}
+
+// firstDate returns the first non-zero date
+func firstDate(dates ...Date) time.Time {
+	for _, d := range dates {
+		if !d.IsZero() {
+			return d.Time
+		}
+	}
+
+	return time.Time{}
+}
+
+// encodeFeed encodes feed to XML
+func encodeFeed(feed any) ([]byte, error) {
+	data, err := xml.MarshalIndent(feed, "", "  ")
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't encode feed: %w", err)
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,197 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IncidentFilter is incident filter function
+type IncidentFilter func(i *Incident) bool
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Filter returns incidents which match all given filters
+func (i Incidents) Filter(filters ...IncidentFilter) Incidents {
+	var result Incidents
+
+INCIDENTS:
+	for _, ii := range i {
+		if ii == nil {
+			continue
+		}
+
+		for _, f := range filters {
+			if f != nil && !f(ii) {
+				continue INCIDENTS
+			}
+		}
+
+		result = append(result, ii)
+	}
+
+	return result
+}
+
+// SortByStartDate returns copy of incidents sorted by start date (oldest first).
+// Nil incidents are dropped.
+func (i Incidents) SortByStartDate() Incidents {
+	return i.sortBy(func(a, b *Incident) int {
+		return a.StartDate.Compare(b.StartDate.Time)
+	})
+}
+
+// SortByDuration returns copy of incidents sorted by duration (shortest first).
+// Nil incidents are dropped.
+func (i Incidents) SortByDuration() Incidents {
+	now := time.Now()
+
+	return i.sortBy(func(a, b *Incident) int {
+		return cmp.Compare(a.elapsed(now), b.elapsed(now))
+	})
+}
+
+// SortByLevel returns copy of incidents sorted by level (minor first).
+// Nil incidents are dropped.
+func (i Incidents) SortByLevel() Incidents {
+	return i.sortBy(func(a, b *Incident) int {
+		return cmp.Compare(a.LevelID, b.LevelID)
+	})
+}
+
+// Reverse returns copy of incidents in reverse order
+func (i Incidents) Reverse() Incidents {
+	result := slices.Clone(i)
+	slices.Reverse(result)
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ByService returns filter for incidents affecting any of given services
+// (by name or slug)
+func ByService(services ...string) IncidentFilter {
+	return func(i *Incident) bool {
+		for _, s := range i.Services {
+			if slices.Contains(services, s.Name) || slices.Contains(services, s.Slug) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// ByZone returns filter for incidents affecting any of given zones
+func ByZone(zones ...string) IncidentFilter {
+	return func(i *Incident) bool {
+		return containsAny(i.ZoneList(), zones)
+	}
+}
+
+// ByRegion returns filter for incidents affecting any of given regions
+func ByRegion(regions ...RegionCode) IncidentFilter {
+	return func(i *Incident) bool {
+		for _, r := range i.Regions {
+			if slices.Contains(regions, r.Code) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// ByLevel returns filter for incidents with any of given levels
+func ByLevel(levels ...LevelID) IncidentFilter {
+	return func(i *Incident) bool {
+		return slices.Contains(levels, i.LevelID)
+	}
+}
+
+// ByStatus returns filter for incidents with any of given statuses
+func ByStatus(statuses ...Status) IncidentFilter {
+	return func(i *Incident) bool {
+		return slices.Contains(statuses, i.Status)
+	}
+}
+
+// LongerThan returns filter for incidents lasted longer than given duration.
+// Duration of open incidents without end date is calculated up to current
+// moment.
+func LongerThan(d time.Duration) IncidentFilter {
+	return func(i *Incident) bool {
+		return i.elapsed(time.Now()) > d
+	}
+}
+
+// Between returns filter for incidents which overlap with given period
+func Between(from, to time.Time) IncidentFilter {
+	return func(i *Incident) bool {
+		return i.overlaps(from, to, time.Now())
+	}
+}
+
+// HasReport returns filter for incidents with published report
+func HasReport() IncidentFilter {
+	return func(i *Incident) bool {
+		return i.IsReportPublished && i.Report != ""
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// interval returns start and end dates of incident. If incident has no end
+// date, given current date is used instead.
+func (i *Incident) interval(now time.Time) (time.Time, time.Time) {
+	if i.EndDate.IsZero() {
+		return i.StartDate.Time, now
+	}
+
+	return i.StartDate.Time, i.EndDate.Time
+}
+
+// overlaps returns true if incident overlaps with given period. If incident
+// has no end date, given current date is used instead.
+func (i *Incident) overlaps(from, to, now time.Time) bool {
+	start, end := i.interval(now)
+	return start.Before(to) && end.After(from)
+}
+
+// elapsed returns incident duration. If incident has no end date, duration
+// is calculated up to given current date.
+func (i *Incident) elapsed(now time.Time) time.Duration {
+	start, end := i.interval(now)
+	return max(end.Sub(start), 0)
+}
+
+// sortBy returns sorted copy of incidents without nil elements
+func (i Incidents) sortBy(fn func(a, b *Incident) int) Incidents {
+	result := slices.DeleteFunc(slices.Clone(i), func(ii *Incident) bool {
+		return ii == nil
+	})
+
+	slices.SortStableFunc(result, fn)
+
+	return result
+}
+
+// containsAny returns true if slice contains any of given items
+func containsAny(items, search []string) bool {
+	for _, item := range items {
+		if slices.Contains(search, item) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,174 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// icsDateFormat is iCalendar UTC date-time format
+const icsDateFormat = "20060102T150405Z"
+
+// icsMaxLineSize is maximum line length in octets (excluding line break)
+const icsMaxLineSize = 75
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// icsReplacer escapes special characters in iCalendar text values
+var icsReplacer = strings.NewReplacer(
+	`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`,
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ICS generates iCalendar (RFC 5545) calendar with one event per incident.
+// Events of open incidents end at the current moment and are marked as
+// tentative, so they grow on every calendar refresh until incident is resolved.
+// Incidents without start date are skipped. Incident URLs use default
+// language.
+func (i Incidents) ICS() []byte {
+	return i.ICSLang("")
+}
+
+// ICSLang generates iCalendar (RFC 5545) calendar the same way as ICS, but
+// incident URLs use given language (LANG_RU if empty)
+func (i Incidents) ICSLang(lang Lang) []byte {
+	lang = Lang(lang.orDefault())
+	now := time.Now().UTC()
+
+	var buf bytes.Buffer
+
+	writeICSLine(&buf, "BEGIN", "VCALENDAR")
+	writeICSLine(&buf, "VERSION", "2.0")
+	writeICSLine(&buf, "PRODID", "-//ESSENTIAL KAOS//YCS.go//EN")
+	writeICSLine(&buf, "CALSCALE", "GREGORIAN")
+	writeICSLine(&buf, "METHOD", "PUBLISH")
+	writeICSLine(&buf, "X-WR-CALNAME", "Yandex Cloud incidents")
+
+	for _, ii := range i {
+		if ii != nil && !ii.StartDate.IsZero() {
+			ii.writeICSEvent(&buf, lang, now)
+		}
+	}
+
+	writeICSLine(&buf, "END", "VCALENDAR")
+
+	return buf.Bytes()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// writeICSEvent writes VEVENT component with incident info
+func (i *Incident) writeICSEvent(buf *bytes.Buffer, lang Lang, now time.Time) {
+	start := i.StartDate.UTC()
+	end := i.EndDate.UTC()
+	summary := i.Title
+	status := "CONFIRMED"
+
+	if i.EndDate.IsZero() {
+		end, status = now, "TENTATIVE"
+		summary = "[" + Status(STATUS_OPEN).Label(lang) + "] " + summary
+	}
+
+	// Zero-length events are hidden by some calendar apps
+	if !end.After(start) {
+		end = start.Add(time.Minute)
+	}
+
+	writeICSLine(buf, "BEGIN", "VEVENT")
+	writeICSLine(buf, "UID", fmt.Sprintf("incident-%d@status.yandex.cloud", i.ID))
+	writeICSLine(buf, "DTSTAMP", now.Format(icsDateFormat))
+	writeICSLine(buf, "DTSTART", start.Format(icsDateFormat))
+	writeICSLine(buf, "DTEND", end.Format(icsDateFormat))
+	writeICSLine(buf, "SUMMARY", icsReplacer.Replace(summary))
+	writeICSLine(buf, "STATUS", status)
+
+	if zones := uniqueStrings(i.ZoneList()); len(zones) != 0 {
+		writeICSLine(buf, "LOCATION", icsReplacer.Replace(strings.Join(zones, ", ")))
+	}
+
+	if services := uniqueStrings(i.ServiceList()); len(services) != 0 {
+		for idx, s := range services {
+			services[idx] = icsReplacer.Replace(s)
+		}
+
+		writeICSLine(buf, "CATEGORIES", strings.Join(services, ","))
+	}
+
+	if url := i.URL(lang); url != "" {
+		writeICSLine(buf, "URL", url)
+	}
+
+	if description := i.icsDescription(lang); description != "" {
+		writeICSLine(buf, "DESCRIPTION", icsReplacer.Replace(description))
+	}
+
+	if !i.CreatedAt.IsZero() {
+		writeICSLine(buf, "CREATED", i.CreatedAt.UTC().Format(icsDateFormat))
+	}
+
+	if !i.UpdatedAt.IsZero() {
+		writeICSLine(buf, "LAST-MODIFIED", i.UpdatedAt.UTC().Format(icsDateFormat))
+	}
+
+	writeICSLine(buf, "END", "VEVENT")
+}
+
+// icsDescription returns event description
+func (i *Incident) icsDescription(lang Lang) string {
+	var body string
+
+	switch {
+	case i.IsReportPublished && i.Report != "":
+		body = i.ReportMarkdown()
+	case i.Comments.Latest() != nil:
+		body = i.Comments.Latest().Markdown()
+	}
+
+	url := i.URL(lang)
+	body = strings.TrimSpace(body)
+
+	switch {
+	case url == "":
+		return body
+	case body == "":
+		return url
+	}
+
+	return url + "\n\n" + body
+}
+
+// writeICSLine writes content line folded to 75 octets
+func writeICSLine(buf *bytes.Buffer, name, value string) {
+	line := name + ":" + value
+	size := icsMaxLineSize
+
+	for len(line) > size {
+		cut := size
+
+		// Don't split multi-byte characters
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+
+		line = line[cut:]
+		size = icsMaxLineSize - 1 // Continuation lines start with space
+	}
+
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,91 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// limiter is requests rate limiter which can be safely used by many goroutines
+type limiter struct {
+	delay time.Duration
+	next  time.Time // Time of the next free slot
+
+	mu sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newLimiter creates new limiter. If rps is less than or equal to 0, it
+// returns nil.
+func newLimiter(rps float64) *limiter {
+	if rps <= 0 {
+		return nil
+	}
+
+	return &limiter{delay: time.Duration(float64(time.Second) / rps)}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Wait blocks until the next time slot becomes available or context is done
+func (l *limiter) Wait(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+
+	slot := time.Now()
+
+	if slot.Before(l.next) {
+		slot = l.next
+	}
+
+	l.next = slot.Add(l.delay)
+
+	l.mu.Unlock()
+
+	wait := time.Until(slot)
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.release(slot)
+		return ctx.Err()
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// release returns unused slot if no other slots were reserved after it
+func (l *limiter) release(slot time.Time) {
+	l.mu.Lock()
+
+	if l.next.Equal(slot.Add(l.delay)) {
+		l.next = slot
+	}
+
+	l.mu.Unlock()
+}
@@ -0,0 +1,547 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/strutil"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// mdBlock is Markdown block
+type mdBlock struct {
+	text   string
+	isList bool
+}
+
+// mdWalker walks HTML tree and converts it to blocks. Separate elements are
+// rendered by callbacks, so the same walker is used for Markdown and text.
+type mdWalker struct {
+	inline    func(w *mdWalker, n *html.Node) string         // renders inline node
+	paragraph func(text string, width int) string            // formats paragraph
+	heading   func(level int, text string, width int) string // formats heading
+	code      func(n *html.Node) string                      // formats preformatted text
+	quote     func(text string) string                       // formats block quote
+	rule      func(width int) string                         // formats horizontal rule
+	table     func(rows [][]string) string                   // formats table
+	marker    func(ordered bool, index int) string           // returns list item marker
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// mdMarkdownWalker converts HTML to Markdown
+var mdMarkdownWalker = &mdWalker{
+	inline:    mdInline,
+	paragraph: mdParagraph,
+	heading:   mdHeading,
+	code:      mdCodeBlock,
+	quote:     mdQuote,
+	rule:      mdRule,
+	table:     mdTable,
+	marker:    mdListMarker,
+}
+
+// mdReplacer escapes characters with special meaning in inline Markdown
+var mdReplacer = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`,
+)
+
+// mdURLReplacer escapes characters which break link destination
+var mdURLReplacer = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29")
+
+// mdBlockStartRegex matches text at the beginning of line which would be
+// interpreted as block marker
+var mdBlockStartRegex = regexp.MustCompile(`^(#{1,6}|[>+-])(\s|$)|^(=+|-+)\s*$`)
+
+// mdOrderedStartRegex matches text at the beginning of line which would be
+// interpreted as ordered list item
+var mdOrderedStartRegex = regexp.MustCompile(`^(\d{1,9})([.)])(\s|$)`)
+
+// mdSpaceRegex matches whitespace sequences
+var mdSpaceRegex = regexp.MustCompile(`\s+`)
+
+// mdBodyNode is context node for parsing HTML fragments
+var mdBodyNode = &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// htmlToMarkdown converts HTML to CommonMark. Tables are converted to GFM tables.
+func htmlToMarkdown(text string) string {
+	if strings.TrimSpace(text) == "" {
+		return ""
+	}
+
+	nodes, err := html.ParseFragment(strings.NewReader(text), mdBodyNode)
+
+	if err != nil {
+		return strings.TrimSpace(text)
+	}
+
+	return joinMDBlocks(mdMarkdownWalker.blocks(nodes, 0), "\n\n")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// blocks converts nodes to blocks. Sequences of inline nodes are merged
+// into paragraphs.
+func (w *mdWalker) blocks(nodes []*html.Node, width int) []mdBlock {
+	var result []mdBlock
+	var inline strings.Builder
+
+	add := func(text string, isList bool) {
+		if strings.TrimSpace(text) != "" {
+			result = append(result, mdBlock{text: text, isList: isList})
+		}
+	}
+
+	flush := func() {
+		add(w.paragraph(inline.String(), width), false)
+		inline.Reset()
+	}
+
+	for _, n := range nodes {
+		if !isHTMLBlock(n) {
+			inline.WriteString(w.inline(w, n))
+			continue
+		}
+
+		flush()
+
+		switch n.DataAtom {
+		case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+			add(w.heading(int(n.Data[1]-'0'), w.inlineChildren(n), width), false)
+
+		case atom.Ul, atom.Ol:
+			add(w.list(n, width), true)
+
+		case atom.Pre:
+			add(w.code(n), false)
+
+		case atom.Blockquote:
+			// Quote marker takes 2 columns
+			if quote := joinMDBlocks(w.blocks(htmlChildren(n), max(width-2, 0)), "\n\n"); quote != "" {
+				add(w.quote(quote), false)
+			}
+
+		case atom.Hr:
+			add(w.rule(width), false)
+
+		case atom.Table:
+			add(w.table(w.tableRows(n)), false)
+
+		case atom.Script, atom.Style, atom.Head, atom.Title:
+			// skip
+
+		default:
+			result = append(result, w.blocks(htmlChildren(n), width)...)
+		}
+	}
+
+	flush()
+
+	return result
+}
+
+// list renders list with indented items
+func (w *mdWalker) list(n *html.Node, width int) string {
+	var items []string
+
+	index := 1
+
+	if n.DataAtom == atom.Ol {
+		if start, err := strconv.Atoi(htmlAttr(n, "start")); err == nil && start >= 0 {
+			index = start
+		}
+	}
+
+	for _, c := range htmlChildren(n) {
+		if c.Type == html.TextNode && strings.TrimSpace(c.Data) == "" {
+			continue
+		}
+
+		marker := w.marker(n.DataAtom == atom.Ol, index)
+		index++
+
+		nodes := []*html.Node{c}
+
+		if c.DataAtom == atom.Li {
+			nodes = htmlChildren(c)
+		}
+
+		size := strutil.LenVisual(marker)
+
+		// Nested lists are placed right after item text to keep list tight
+		var content strings.Builder
+
+		for i, b := range w.blocks(nodes, max(width-size, 0)) {
+			switch {
+			case i == 0:
+			case b.isList:
+				content.WriteString("\n")
+			default:
+				content.WriteString("\n\n")
+			}
+
+			content.WriteString(b.text)
+		}
+
+		if content.Len() == 0 {
+			items = append(items, strings.TrimSpace(marker))
+			continue
+		}
+
+		indent := strings.Repeat(" ", size)
+		items = append(items, marker+strings.TrimPrefix(prefixLines(content.String(), indent, ""), indent))
+	}
+
+	return strings.Join(items, "\n")
+}
+
+// tableRows returns inline content of table cells
+func (w *mdWalker) tableRows(n *html.Node) [][]string {
+	var rows [][]string
+
+	for _, c := range htmlChildren(n) {
+		switch c.DataAtom {
+		case atom.Thead, atom.Tbody, atom.Tfoot:
+			rows = append(rows, w.tableRows(c)...)
+
+		case atom.Tr:
+			var row []string
+
+			for _, cell := range htmlChildren(c) {
+				if cell.DataAtom == atom.Th || cell.DataAtom == atom.Td {
+					row = append(row, w.inlineChildren(cell))
+				}
+			}
+
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+// inlineChildren renders all children of node as inline text
+func (w *mdWalker) inlineChildren(n *html.Node) string {
+	var buf strings.Builder
+
+	for _, c := range htmlChildren(n) {
+		if isHTMLBlock(c) {
+			// Blocks inside inline elements (e.g. <p> inside <td>) are merged
+			buf.WriteString(" " + joinMDBlocks(w.blocks([]*html.Node{c}, 0), " ") + " ")
+		} else {
+			buf.WriteString(w.inline(w, c))
+		}
+	}
+
+	return buf.String()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// mdParagraph formats paragraph text
+func mdParagraph(text string, _ int) string {
+	var lines []string
+
+	for line := range strings.SplitSeq(strings.TrimSpace(text), "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case mdBlockStartRegex.MatchString(line):
+			line = `\` + line
+		case mdOrderedStartRegex.MatchString(line):
+			line = mdOrderedStartRegex.ReplaceAllString(line, `$1\$2$3`)
+		}
+
+		lines = append(lines, line)
+	}
+
+	// Lines are separated by hard line breaks
+	return strings.Join(lines, "  \n")
+}
+
+// mdHeading formats ATX heading
+func mdHeading(level int, text string, _ int) string {
+	text = strings.TrimSpace(mdSpaceRegex.ReplaceAllString(text, " "))
+
+	if text == "" {
+		return ""
+	}
+
+	return strings.Repeat("#", level) + " " + text
+}
+
+// mdListMarker returns marker of list item
+func mdListMarker(ordered bool, index int) string {
+	if ordered {
+		return strconv.Itoa(index) + ". "
+	}
+
+	return "- "
+}
+
+// mdCodeBlock converts preformatted text to fenced code block
+func mdCodeBlock(n *html.Node) string {
+	var lang string
+
+	children := htmlChildren(n)
+
+	if len(children) == 1 && children[0].DataAtom == atom.Code {
+		for _, class := range strings.Fields(htmlAttr(children[0], "class")) {
+			if strings.HasPrefix(class, "language-") {
+				lang = strings.TrimPrefix(class, "language-")
+			}
+		}
+	}
+
+	code := strings.TrimRight(htmlText(n), "\n")
+	code = strings.TrimPrefix(code, "\n")
+	fence := "```"
+
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+
+	return fence + lang + "\n" + code + "\n" + fence
+}
+
+// mdQuote formats block quote
+func mdQuote(text string) string {
+	return prefixLines(text, "> ", ">")
+}
+
+// mdRule returns thematic break
+func mdRule(_ int) string {
+	return "---"
+}
+
+// mdTable converts table to GFM table
+func mdTable(rows [][]string) string {
+	for _, row := range rows {
+		for i, cell := range row {
+			cell = strings.TrimSpace(mdSpaceRegex.ReplaceAllString(cell, " "))
+			row[i] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+	}
+
+	columns := 0
+
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+
+	if columns == 0 {
+		return ""
+	}
+
+	var buf strings.Builder
+
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+
+		buf.WriteString("| " + strings.Join(row, " | ") + " |\n")
+
+		if i == 0 {
+			buf.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+		}
+	}
+
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// mdInline converts inline node to Markdown. Line breaks are represented
+// as new lines.
+func mdInline(w *mdWalker, n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return mdReplacer.Replace(mdSpaceRegex.ReplaceAllString(n.Data, " "))
+	case html.ElementNode:
+		// continue
+	default:
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		return "\n"
+
+	case atom.B, atom.Strong:
+		return mdWrap(w.inlineChildren(n), "**")
+
+	case atom.I, atom.Em:
+		return mdWrap(w.inlineChildren(n), "*")
+
+	case atom.Code, atom.Kbd, atom.Samp, atom.Tt:
+		return mdCodeSpan(htmlText(n))
+
+	case atom.A:
+		text := strings.TrimSpace(w.inlineChildren(n))
+		href := strings.TrimSpace(htmlAttr(n, "href"))
+
+		switch {
+		case href == "":
+			return text
+		case text == "":
+			text = mdReplacer.Replace(href)
+		}
+
+		return "[" + text + "](" + mdURLReplacer.Replace(href) + ")"
+
+	case atom.Img:
+		src := strings.TrimSpace(htmlAttr(n, "src"))
+
+		if src == "" {
+			return ""
+		}
+
+		alt := mdSpaceRegex.ReplaceAllString(htmlAttr(n, "alt"), " ")
+
+		return "![" + mdReplacer.Replace(strings.TrimSpace(alt)) + "](" + mdURLReplacer.Replace(src) + ")"
+
+	case atom.Script, atom.Style:
+		return ""
+	}
+
+	return w.inlineChildren(n)
+}
+
+// mdWrap wraps text with emphasis markers. Surrounding whitespace is moved
+// outside of markers, because otherwise emphasis is not recognized.
+func mdWrap(text, marker string) string {
+	trimmed := strings.TrimSpace(text)
+
+	if trimmed == "" {
+		return text
+	}
+
+	start := text[:strings.Index(text, trimmed)]
+	end := text[len(start)+len(trimmed):]
+
+	return start + marker + trimmed + marker + end
+}
+
+// mdCodeSpan formats inline code
+func mdCodeSpan(code string) string {
+	code = mdSpaceRegex.ReplaceAllString(code, " ")
+
+	if code == "" {
+		return ""
+	}
+
+	fence := "`"
+
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+
+	return fence + code + fence
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isHTMLBlock returns true if node is block element
+func isHTMLBlock(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+
+	switch n.DataAtom {
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer,
+		atom.Main, atom.Aside, atom.Nav, atom.Figure, atom.Figcaption,
+		atom.Details, atom.Summary, atom.Dl, atom.Dt, atom.Dd, atom.Address,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6,
+		atom.Ul, atom.Ol, atom.Li, atom.Pre, atom.Blockquote, atom.Hr,
+		atom.Table, atom.Script, atom.Style, atom.Head, atom.Title:
+		return true
+	}
+
+	return false
+}
+
+// joinMDBlocks joins texts of blocks
+func joinMDBlocks(blocks []mdBlock, sep string) string {
+	var texts []string
+
+	for _, b := range blocks {
+		texts = append(texts, b.text)
+	}
+
+	return strings.Join(texts, sep)
+}
+
+// prefixLines adds prefix to every line of text. Empty lines get emptyPrefix.
+func prefixLines(text, prefix, emptyPrefix string) string {
+	lines := strings.Split(text, "\n")
+
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = emptyPrefix
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// htmlChildren returns slice with node children
+func htmlChildren(n *html.Node) []*html.Node {
+	var result []*html.Node
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		result = append(result, c)
+	}
+
+	return result
+}
+
+// htmlText returns raw text content of node
+func htmlText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+
+	var buf strings.Builder
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.DataAtom == atom.Br {
+			buf.WriteString("\n")
+		} else {
+			buf.WriteString(htmlText(c))
+		}
+	}
+
+	return buf.String()
+}
+
+// htmlAttr returns value of node attribute
+func htmlAttr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,190 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// maxRetryDelay is maximum delay between attempts if MaxDelay is not set
+const maxRetryDelay = 24 * time.Hour
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// RetryPolicy contains configuration of retries for failed requests
+type RetryPolicy struct {
+	MaxAttempts int           // Maximum number of attempts (1 or more)
+	MinDelay    time.Duration // Delay before the second attempt
+	MaxDelay    time.Duration // Maximum delay between attempts
+	Jitter      float64       // Random delay deviation (0-1)
+
+	IgnoreRetryAfter bool // Ignore Retry-After header
+
+	OnAttempt func(a Attempt) // Hook called after every attempt
+}
+
+// Attempt contains info about request attempt
+type Attempt struct {
+	Endpoint   string        // API endpoint
+	Num        int           // Attempt number (starting from 1)
+	StatusCode int           // HTTP status code (0 if request failed)
+	Err        error         // Request error
+	Delay      time.Duration // Delay before the next attempt (0 if there will be no more attempts)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DefaultRetryPolicy is recommended retry policy
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinDelay:    500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+	Jitter:      0.2,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetRetryPolicy sets retry policy for default client
+func SetRetryPolicy(policy RetryPolicy) error {
+	return defaultClient.SetRetryPolicy(policy)
+}
+
+// ParseRetryAfter parses Retry-After header value (delay in seconds or HTTP
+// date) and returns requested pause
+func ParseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	seconds, err := strconv.ParseUint(value, 10, 32)
+
+	if err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	date, err := http.ParseTime(value)
+
+	if err != nil {
+		return 0
+	}
+
+	return max(time.Until(date), 0)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetRetryPolicy sets retry policy
+func (c *Client) SetRetryPolicy(policy RetryPolicy) error {
+	if c == nil {
+		return ErrNilClient
+	}
+
+	err := policy.Validate()
+
+	if err != nil {
+		return err
+	}
+
+	c.retry = policy
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate validates retry policy
+func (p RetryPolicy) Validate() error {
+	switch {
+	case p.MaxAttempts < 0:
+		return fmt.Errorf("Number of attempts must be equal or greater than 0 (%d < 0)", p.MaxAttempts)
+	case p.MinDelay < 0:
+		return fmt.Errorf("Minimal delay can't be negative (%v)", p.MinDelay)
+	case p.MaxDelay < 0:
+		return fmt.Errorf("Maximum delay can't be negative (%v)", p.MaxDelay)
+	case p.MaxDelay != 0 && p.MaxDelay < p.MinDelay:
+		return fmt.Errorf("Maximum delay must be greater than minimal delay (%v < %v)", p.MaxDelay, p.MinDelay)
+	case p.Jitter < 0 || p.Jitter > 1:
+		return fmt.Errorf("Jitter must be in range 0-1 (%g)", p.Jitter)
+	}
+
+	return nil
+}
+
+// Delay returns delay before the next attempt after attempt with given number
+// (starting from 1) and pause requested by server via Retry-After header (0 if
+// not set). Both values (including jitter) are limited by MaxDelay or by 24
+// hours if MaxDelay is not set.
+func (p RetryPolicy) Delay(attempt int, retryAfter time.Duration) time.Duration {
+	maxDelay := p.MaxDelay
+
+	if maxDelay == 0 {
+		maxDelay = maxRetryDelay
+	}
+
+	if retryAfter > 0 && !p.IgnoreRetryAfter {
+		return min(retryAfter, maxDelay)
+	}
+
+	delay := min(p.MinDelay, maxDelay)
+
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		if delay > maxDelay/2 {
+			delay = maxDelay
+		} else {
+			delay *= 2
+		}
+	}
+
+	if p.Jitter > 0 && delay > 0 {
+		jitter := time.Duration(float64(delay) * p.Jitter * (rand.Float64()*2 - 1))
+
+		if jitter > maxDelay-delay {
+			delay = maxDelay
+		} else {
+			delay += jitter
+		}
+	}
+
+	return delay
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// attempts returns maximum number of attempts
+func (p RetryPolicy) attempts() int {
+	return max(p.MaxAttempts, 1)
+}
+
+// notify calls attempt hook
+func (p RetryPolicy) notify(a Attempt) {
+	if p.OnAttempt == nil {
+		return
+	}
+
+	var apiErr *APIError
+
+	if errors.As(a.Err, &apiErr) {
+		a.StatusCode = apiErr.StatusCode
+	}
+
+	p.OnAttempt(a)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isRetryableStatus returns true if request with given status code can be retried
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
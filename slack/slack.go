@@ -0,0 +1,605 @@
+package slack
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/essentialkaos/ycs"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// API_URL is default Slack Web API URL
+const API_URL = "https://slack.com/api"
+
+const (
+	COLOR_DANGER   = "#E01E5A"
+	COLOR_NORMAL   = "#ECB22E"
+	COLOR_RESOLVED = "#2EB67D"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Config contains notifier configuration
+type Config struct {
+	// WebhookURL is incoming webhook URL. Incoming webhooks don't return
+	// message ID, so messages sent using webhook can't be threaded.
+	WebhookURL string
+
+	// Token is bot token used for sending messages using Web API. If token is
+	// set, updates are posted as replies in the thread of the original message.
+	Token string
+
+	// Channel is channel ID (required if Token is set)
+	Channel string
+
+	// APIURL is Slack Web API URL (API_URL by default)
+	APIURL string
+
+	// Lang is language of labels (ycs.LANG_EN by default)
+	Lang ycs.Lang
+
+	// HTTPClient is HTTP client used for sending requests (http.DefaultClient
+	// by default)
+	HTTPClient *http.Client
+}
+
+// Notifier sends incident events to Slack. Updates of incident are posted as
+// replies in the thread of the first message only if Token is set. Incoming
+// webhooks don't support threads, so with WebhookURL every event is posted as
+// a separate message.
+type Notifier struct {
+	cfg     Config
+	threads map[uint]string
+	mu      sync.Mutex
+}
+
+// Message is Slack message
+type Message struct {
+	Channel     string        `json:"channel,omitempty"`
+	Text        string        `json:"text"`
+	ThreadTS    string        `json:"thread_ts,omitempty"`
+	Attachments []*Attachment `json:"attachments,omitempty"`
+}
+
+// Attachment is message attachment with colored bar
+type Attachment struct {
+	Color  string   `json:"color"`
+	Blocks []*Block `json:"blocks"`
+}
+
+// Block is Block Kit layout block
+type Block struct {
+	Type     string  `json:"type"`
+	Text     *Text   `json:"text,omitempty"`
+	Fields   []*Text `json:"fields,omitempty"`
+	Elements []*Text `json:"elements,omitempty"`
+}
+
+// Text is Block Kit text object
+type Text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// apiResponse is Web API response
+type apiResponse struct {
+	OK    bool   `json:"ok"`
+	TS    string `json:"ts"`
+	Error string `json:"error"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	mdLinkRegex      = regexp.MustCompile(`!?\[([^\]]*)\]\(([^)\s]+)\)`)
+	mdBoldRegex      = regexp.MustCompile(`\*\*([^*\n]+)\*\*`)
+	mdItalicRegex    = regexp.MustCompile(`\*([^*\n]+)\*`)
+	mdEscapeRegex    = regexp.MustCompile("\\\\([!-/:-@\\[-`{-~])")
+	mdHeadingRegex   = regexp.MustCompile(`^#{1,6}\s+(.+)$`)
+	mdQuoteRegex     = regexp.MustCompile(`^(>\s?)+`)
+	mdFenceRegex     = regexp.MustCompile("^`{3,}")
+	mdTableRuleRegex = regexp.MustCompile(`^\|(\s*:?-+:?\s*\|)+\s*$`)
+)
+
+// entityReplacer escapes characters with special meaning in mrkdwn
+var entityReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// escapeBase is the first rune of private use area used as placeholders for
+// escaped characters
+const escapeBase = 0xE000
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewNotifier creates new Slack notifier
+func NewNotifier(cfg Config) (*Notifier, error) {
+	switch {
+	case cfg.WebhookURL == "" && cfg.Token == "":
+		return nil, fmt.Errorf("Webhook URL or token must be set")
+	case cfg.Token != "" && cfg.Channel == "":
+		return nil, fmt.Errorf("Channel must be set for sending messages using Web API")
+	}
+
+	if cfg.APIURL == "" {
+		cfg.APIURL = API_URL
+	}
+
+	if cfg.Lang == "" {
+		cfg.Lang = ycs.LANG_EN
+	}
+
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+
+	cfg.APIURL = strings.TrimRight(cfg.APIURL, "/")
+
+	return &Notifier{cfg: cfg, threads: map[uint]string{}}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Notify sends message for given event. Events without incident (errors,
+// services changes) are ignored.
+func (n *Notifier) Notify(ctx context.Context, e ycs.Event) error {
+	if n == nil {
+		return fmt.Errorf("Notifier is nil")
+	}
+
+	if e.Incident == nil {
+		return nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	msg := n.Message(e)
+
+	if msg == nil {
+		return nil
+	}
+
+	if n.cfg.Token == "" {
+		return n.sendWebhook(ctx, msg)
+	}
+
+	msg.Channel = n.cfg.Channel
+	msg.ThreadTS = n.threads[e.Incident.ID]
+
+	ts, err := n.sendAPI(ctx, msg)
+
+	if err != nil {
+		return err
+	}
+
+	if msg.ThreadTS == "" {
+		n.threads[e.Incident.ID] = ts
+	}
+
+	return nil
+}
+
+// SetThread sets ID (ts) of the original message for given incident. Thread
+// is used only for sending messages using Web API.
+func (n *Notifier) SetThread(incidentID uint, ts string) {
+	if n == nil {
+		return
+	}
+
+	n.mu.Lock()
+	n.threads[incidentID] = ts
+	n.mu.Unlock()
+}
+
+// Thread returns ID (ts) of the original message for given incident
+func (n *Notifier) Thread(incidentID uint) string {
+	if n == nil {
+		return ""
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.threads[incidentID]
+}
+
+// Message creates Slack message for given event
+func (n *Notifier) Message(e ycs.Event) *Message {
+	if n == nil || e.Incident == nil {
+		return nil
+	}
+
+	i := e.Incident
+	lang := n.cfg.Lang
+
+	var header string
+
+	switch e.Type {
+	case ycs.EVENT_INCIDENT_OPENED:
+		header = "🚨 " + i.Title
+	case ycs.EVENT_INCIDENT_UPDATED:
+		header = "🔄 " + i.Title
+	case ycs.EVENT_INCIDENT_RESOLVED:
+		header = "✅ " + i.Title
+	case ycs.EVENT_REPORT_PUBLISHED:
+		header = "📄 " + i.Title
+	case ycs.EVENT_LEVEL_CHANGED:
+		header = "⚠️ " + i.Title
+	default:
+		return nil
+	}
+
+	blocks := []*Block{
+		{Type: "header", Text: &Text{"plain_text", truncate(header, 150)}},
+		{Type: "section", Fields: []*Text{
+			{"mrkdwn", fmt.Sprintf("*Level:*\n%s", i.LevelID.Label(lang))},
+			{"mrkdwn", fmt.Sprintf("*Status:*\n%s", i.Status.Label(lang))},
+			{"mrkdwn", fmt.Sprintf("*Regions:*\n%s", formatList(i.RegionList()))},
+			{"mrkdwn", fmt.Sprintf("*Zones:*\n%s", formatList(i.ZoneList()))},
+		}},
+		{Type: "section", Text: &Text{"mrkdwn", fmt.Sprintf("*Services:* %s", formatList(i.ServiceList()))}},
+	}
+
+	comment := e.Comments.Latest()
+
+	if comment == nil {
+		comment = i.Comments.Latest()
+	}
+
+	if comment != nil {
+		blocks = append(blocks, &Block{
+			Type: "section",
+			Text: &Text{"mrkdwn", truncate(Mrkdwn(comment.Markdown()), 3000)},
+		})
+	}
+
+	if e.Type == ycs.EVENT_REPORT_PUBLISHED && i.Report != "" {
+		blocks = append(blocks, &Block{
+			Type: "section",
+			Text: &Text{"mrkdwn", truncate(Mrkdwn(i.ReportMarkdown()), 3000)},
+		})
+	}
+
+	blocks = append(blocks, &Block{
+		Type: "context",
+		Elements: []*Text{
+			{"mrkdwn", fmt.Sprintf("<%s|Incident #%d>", i.URL(lang), i.ID)},
+		},
+	})
+
+	return &Message{
+		Text: fmt.Sprintf("%s: %s", i.LevelID.Label(lang), i.Title),
+		Attachments: []*Attachment{
+			{Color: incidentColor(i), Blocks: blocks},
+		},
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Mrkdwn converts Markdown to Slack mrkdwn format. Slack doesn't support
+// headings and tables, so headings are converted to bold lines and tables
+// are placed into code blocks.
+func Mrkdwn(text string) string {
+	var result []string
+
+	lines := strings.Split(text, "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case mdFenceRegex.MatchString(line):
+			fence := mdFenceRegex.FindString(line)
+
+			var code []string
+
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != fence; i++ {
+				code = append(code, lines[i])
+			}
+
+			result = append(result, "```\n"+entityReplacer.Replace(strings.Join(code, "\n"))+"\n```")
+
+		case strings.HasPrefix(line, "|") && i+1 < len(lines) && mdTableRuleRegex.MatchString(lines[i+1]):
+			var rows [][]string
+
+			for ; i < len(lines) && strings.HasPrefix(lines[i], "|"); i++ {
+				if !mdTableRuleRegex.MatchString(lines[i]) {
+					rows = append(rows, tableCells(lines[i]))
+				}
+			}
+
+			i--
+
+			result = append(result, "```\n"+entityReplacer.Replace(formatTable(rows))+"\n```")
+
+		case mdHeadingRegex.MatchString(line):
+			heading := mdHeadingRegex.FindStringSubmatch(line)[1]
+			result = append(result, "*"+inlineMrkdwn(mdBoldRegex.ReplaceAllString(heading, "$1"))+"*")
+
+		case mdQuoteRegex.MatchString(line):
+			quote := inlineMrkdwn(mdQuoteRegex.ReplaceAllString(line, ""))
+			result = append(result, strings.TrimRight("> "+quote, " "))
+
+		default:
+			result = append(result, inlineMrkdwn(line))
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// sendWebhook sends message using incoming webhook
+func (n *Notifier) sendWebhook(ctx context.Context, msg *Message) error {
+	resp, err := n.post(ctx, n.cfg.WebhookURL, msg)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Webhook returned non-ok status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// sendAPI sends message using Web API and returns message ID (ts)
+func (n *Notifier) sendAPI(ctx context.Context, msg *Message) (string, error) {
+	resp, err := n.post(ctx, n.cfg.APIURL+"/chat.postMessage", msg)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return "", fmt.Errorf("API returned non-ok status code %d", resp.StatusCode)
+	}
+
+	apiResp := &apiResponse{}
+	err = json.NewDecoder(resp.Body).Decode(apiResp)
+
+	if err != nil {
+		return "", fmt.Errorf("Can't decode API response: %w", err)
+	}
+
+	if !apiResp.OK {
+		return "", fmt.Errorf("API returned error: %s", apiResp.Error)
+	}
+
+	return apiResp.TS, nil
+}
+
+// post sends JSON-encoded data to given URL
+func (n *Notifier) post(ctx context.Context, url string, data any) (*http.Response, error) {
+	body, err := json.Marshal(data)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't encode message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("User-Agent", ycs.UA)
+
+	if n.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.cfg.Token)
+	}
+
+	resp, err := n.cfg.HTTPClient.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't send message: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// incidentColor returns color of attachment bar for given incident
+func incidentColor(i *ycs.Incident) string {
+	switch {
+	case i.IsResolved():
+		return COLOR_RESOLVED
+	case i.Level != nil && i.Level.Theme == "danger",
+		i.Level == nil && i.LevelID == ycs.LEVEL_ID_UNAVAILABLE:
+		return COLOR_DANGER
+	}
+
+	return COLOR_NORMAL
+}
+
+// formatList formats slice as comma-separated list
+func formatList(items []string) string {
+	if len(items) == 0 {
+		return "—"
+	}
+
+	return strings.Join(items, ", ")
+}
+
+// truncate truncates text to given number of characters
+func truncate(text string, size int) string {
+	runes := []rune(text)
+
+	if len(runes) <= size {
+		return text
+	}
+
+	return string(runes[:size-1]) + "…"
+}
+
+// restoreEscaped replaces placeholders with escaped characters
+func restoreEscaped(text string) string {
+	var buf strings.Builder
+
+	for _, r := range text {
+		switch {
+		case r < escapeBase || r > escapeBase+0x7F:
+			buf.WriteRune(r)
+		case r == escapeBase+'&':
+			buf.WriteString("&amp;")
+		case r == escapeBase+'<':
+			buf.WriteString("&lt;")
+		case r == escapeBase+'>':
+			buf.WriteString("&gt;")
+		default:
+			buf.WriteRune(r - escapeBase)
+		}
+	}
+
+	return buf.String()
+}
+
+// inlineMrkdwn converts inline Markdown to Slack mrkdwn format
+func inlineMrkdwn(text string) string {
+	text = entityReplacer.Replace(hideEscaped(text))
+
+	text = mdLinkRegex.ReplaceAllStringFunc(text, func(found string) string {
+		m := mdLinkRegex.FindStringSubmatch(found)
+
+		if m[1] == "" {
+			return "<" + m[2] + ">"
+		}
+
+		return "<" + m[2] + "|" + m[1] + ">"
+	})
+
+	// Use placeholder for bold text to avoid processing it as italic
+	text = mdBoldRegex.ReplaceAllString(text, "\x00$1\x00")
+	text = mdItalicRegex.ReplaceAllString(text, "_${1}_")
+	text = strings.ReplaceAll(text, "\x00", "*")
+
+	return restoreEscaped(text)
+}
+
+// plainText converts inline Markdown to plain text
+func plainText(text string) string {
+	text = hideEscaped(strings.TrimSpace(text))
+
+	text = mdLinkRegex.ReplaceAllStringFunc(text, func(found string) string {
+		m := mdLinkRegex.FindStringSubmatch(found)
+
+		if m[1] == "" {
+			return m[2]
+		}
+
+		return m[1] + " (" + m[2] + ")"
+	})
+
+	text = mdBoldRegex.ReplaceAllString(text, "$1")
+	text = mdItalicRegex.ReplaceAllString(text, "$1")
+
+	return strings.Map(func(r rune) rune {
+		if r >= escapeBase && r <= escapeBase+0x7F {
+			return r - escapeBase
+		}
+
+		return r
+	}, text)
+}
+
+// tableCells returns plain text of cells in GFM table row
+func tableCells(row string) []string {
+	row = strings.TrimPrefix(strings.TrimSpace(row), "|")
+
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, `\|`) {
+		row = row[:len(row)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row):
+			cell.WriteString(row[i : i+2])
+			i++
+		case row[i] == '|':
+			cells = append(cells, plainText(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+
+	return append(cells, plainText(cell.String()))
+}
+
+// formatTable formats table rows as text with aligned columns
+func formatTable(rows [][]string) string {
+	var sizes []int
+
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(sizes) {
+				sizes = append(sizes, 0)
+			}
+
+			sizes[i] = max(sizes[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	var lines []string
+
+	for i, row := range rows {
+		var cells []string
+
+		for j, cell := range row {
+			cells = append(cells, cell+strings.Repeat(" ", sizes[j]-utf8.RuneCountInString(cell)))
+		}
+
+		lines = append(lines, strings.TrimRight(strings.Join(cells, "  "), " "))
+
+		if i == 0 {
+			var rule []string
+
+			for _, size := range sizes {
+				rule = append(rule, strings.Repeat("-", size))
+			}
+
+			lines = append(lines, strings.Join(rule, "  "))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// hideEscaped replaces escaped characters with placeholders to avoid
+// processing them as markup
+func hideEscaped(text string) string {
+	return mdEscapeRegex.ReplaceAllStringFunc(text, func(found string) string {
+		return string(rune(escapeBase + int(found[1])))
+	})
+}
@@ -0,0 +1,224 @@
+package slack
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/essentialkaos/ycs"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type SlackSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&SlackSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// slackStub is a local stand-in for Slack webhooks and Web API
+type slackStub struct {
+	messages []*Message
+	tokens   []string
+	mu       sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *SlackSuite) TestNotifierErrors(c *C) {
+	_, err := NewNotifier(Config{})
+	c.Assert(err, NotNil)
+
+	_, err = NewNotifier(Config{Token: "xoxb-test"})
+	c.Assert(err, NotNil)
+
+	var n *Notifier
+
+	c.Assert(n.Notify(context.Background(), ycs.Event{}), NotNil)
+	c.Assert(n.Message(ycs.Event{}), IsNil)
+	c.Assert(n.Thread(1), Equals, "")
+	n.SetThread(1, "1.0")
+
+	stub := &slackStub{}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	n, err = NewNotifier(Config{WebhookURL: server.URL + "/broken"})
+	c.Assert(err, IsNil)
+
+	incident := loadIncident(c)
+	ev := ycs.Event{Type: ycs.EVENT_INCIDENT_OPENED, Incident: incident}
+
+	c.Assert(n.Notify(context.Background(), ev), ErrorMatches, "Webhook returned non-ok status code 500")
+	c.Assert(n.Notify(context.Background(), ycs.Event{Type: ycs.EVENT_ERROR}), IsNil)
+
+	n, err = NewNotifier(Config{Token: "xoxb-bad", Channel: "C1", APIURL: server.URL})
+	c.Assert(err, IsNil)
+	c.Assert(n.Notify(context.Background(), ev), ErrorMatches, "API returned error: invalid_auth")
+
+	n, err = NewNotifier(Config{WebhookURL: "http://127.0.0.1:1/hook"})
+	c.Assert(err, IsNil)
+	c.Assert(n.Notify(context.Background(), ev), NotNil)
+}
+
+func (s *SlackSuite) TestWebhook(c *C) {
+	stub := &slackStub{}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	n, err := NewNotifier(Config{WebhookURL: server.URL + "/hook"})
+	c.Assert(err, IsNil)
+
+	incident := loadIncident(c)
+
+	err = n.Notify(context.Background(), ycs.Event{Type: ycs.EVENT_INCIDENT_OPENED, Incident: incident})
+	c.Assert(err, IsNil)
+	c.Assert(stub.messages, HasLen, 1)
+
+	msg := stub.messages[0]
+
+	c.Assert(msg.ThreadTS, Equals, "")
+	c.Assert(msg.Attachments, HasLen, 1)
+	c.Assert(msg.Attachments[0].Color, Equals, COLOR_RESOLVED)
+
+	blocks := msg.Attachments[0].Blocks
+
+	c.Assert(blocks[0].Type, Equals, "header")
+	c.Assert(blocks[0].Text.Text, Equals, "🚨 "+incident.Title)
+	c.Assert(blocks[1].Fields, HasLen, 4)
+	c.Assert(blocks[1].Fields[0].Text, Equals, "*Level:*\n"+incident.LevelID.Label(ycs.LANG_EN))
+	c.Assert(blocks[2].Text.Text, Matches, `\*Services:\* .+`)
+	c.Assert(blocks[len(blocks)-1].Elements[0].Text, Equals, "<"+incident.URL(ycs.LANG_EN)+"|Incident #972>")
+
+	long := *incident
+	long.Title = strings.Repeat("Очень длинный заголовок ", 10)
+	header := n.Message(ycs.Event{Type: ycs.EVENT_INCIDENT_OPENED, Incident: &long}).Attachments[0].Blocks[0].Text.Text
+
+	c.Assert(utf8.RuneCountInString(header), Equals, 150)
+	c.Assert(strings.HasSuffix(header, "…"), Equals, true)
+}
+
+func (s *SlackSuite) TestThreads(c *C) {
+	stub := &slackStub{}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	n, err := NewNotifier(Config{Token: "xoxb-test", Channel: "C1", APIURL: server.URL + "/"})
+	c.Assert(err, IsNil)
+
+	incident := loadIncident(c)
+	ctx := context.Background()
+
+	c.Assert(n.Notify(ctx, ycs.Event{Type: ycs.EVENT_INCIDENT_OPENED, Incident: incident}), IsNil)
+	c.Assert(n.Thread(incident.ID), Equals, "1000.1")
+
+	c.Assert(n.Notify(ctx, ycs.Event{
+		Type: ycs.EVENT_INCIDENT_UPDATED, Incident: incident,
+		Comments: ycs.Comments{incident.Comments.Get(0)},
+	}), IsNil)
+	c.Assert(n.Notify(ctx, ycs.Event{Type: ycs.EVENT_INCIDENT_RESOLVED, Incident: incident}), IsNil)
+	c.Assert(n.Notify(ctx, ycs.Event{Type: ycs.EVENT_SERVICE_ADDED, Incident: incident}), IsNil)
+
+	c.Assert(stub.messages, HasLen, 3)
+	c.Assert(stub.tokens, DeepEquals, []string{"Bearer xoxb-test", "Bearer xoxb-test", "Bearer xoxb-test"})
+	c.Assert(stub.messages[0].Channel, Equals, "C1")
+	c.Assert(stub.messages[0].ThreadTS, Equals, "")
+	c.Assert(stub.messages[1].ThreadTS, Equals, "1000.1")
+	c.Assert(stub.messages[2].ThreadTS, Equals, "1000.1")
+	c.Assert(stub.messages[2].Attachments[0].Blocks[0].Text.Text, Equals, "✅ "+incident.Title)
+	c.Assert(n.Thread(incident.ID), Equals, "1000.1")
+
+	n.SetThread(1, "2000.1")
+	c.Assert(n.Thread(1), Equals, "2000.1")
+}
+
+func (s *SlackSuite) TestColors(c *C) {
+	c.Assert(incidentColor(&ycs.Incident{Level: &ycs.Level{Theme: "danger"}}), Equals, COLOR_DANGER)
+	c.Assert(incidentColor(&ycs.Incident{Level: &ycs.Level{Theme: "normal"}}), Equals, COLOR_NORMAL)
+	c.Assert(incidentColor(&ycs.Incident{LevelID: ycs.LEVEL_ID_UNAVAILABLE}), Equals, COLOR_DANGER)
+	c.Assert(incidentColor(&ycs.Incident{LevelID: ycs.LEVEL_ID_MINOR}), Equals, COLOR_NORMAL)
+}
+
+func (s *SlackSuite) TestMrkdwn(c *C) {
+	c.Assert(Mrkdwn("**Bold** and *italic*"), Equals, "*Bold* and _italic_")
+	c.Assert(Mrkdwn("[Link](https://domain.com) & <tag>"), Equals, "<https://domain.com|Link> &amp; &lt;tag&gt;")
+	c.Assert(Mrkdwn("![](https://domain.com/img.png)"), Equals, "<https://domain.com/img.png>")
+	c.Assert(Mrkdwn("- item\n- item"), Equals, "- item\n- item")
+	c.Assert(Mrkdwn(`2 \* 3 \* 4, snake\_case, \<tag> & \[x\]`), Equals, "2 * 3 * 4, snake_case, &lt;tag&gt; &amp; [x]")
+	c.Assert(Mrkdwn(`![Chart \[1\]](https://domain.com/img.png)`), Equals, "<https://domain.com/img.png|Chart [1]>")
+	c.Assert(Mrkdwn("## Title **x**\n\nText\n\\# Not a heading"), Equals, "*Title x*\n\nText\n# Not a heading")
+	c.Assert(Mrkdwn("> Quote *x* & y\n>\n> > Nested"), Equals, "> Quote _x_ &amp; y\n>\n> Nested")
+	c.Assert(Mrkdwn("```go\nif a < b && **c** {\n```\nText"), Equals, "```\nif a &lt; b &amp;&amp; **c** {\n```\nText")
+	c.Assert(
+		Mrkdwn("Zones:\n\n| Zone | Status |\n| --- | --- |\n| ru-central1-a | **Down** \\| [partial](https://a.com) |\n| kz1-a |  |"),
+		Equals, "Zones:\n\n```\nZone           Status\n-------------  ------------------------------\nru-central1-a  Down | partial (https://a.com)\nkz1-a\n```",
+	)
+	c.Assert(Mrkdwn("| Not a table |\nText"), Equals, "| Not a table |\nText")
+
+	c.Assert(formatList(nil), Equals, "—")
+	c.Assert(formatList([]string{"a", "b"}), Equals, "a, b")
+	c.Assert(truncate("abcdef", 10), Equals, "abcdef")
+	c.Assert(truncate("abcdef", 4), Equals, "abc…")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *slackStub) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/broken" {
+		rw.WriteHeader(500)
+		return
+	}
+
+	msg := &Message{}
+	json.NewDecoder(r.Body).Decode(msg)
+
+	if r.URL.Path != "/chat.postMessage" {
+		s.messages = append(s.messages, msg)
+		rw.Write([]byte("ok"))
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer xoxb-test" {
+		rw.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+		return
+	}
+
+	s.messages = append(s.messages, msg)
+	s.tokens = append(s.tokens, r.Header.Get("Authorization"))
+
+	rw.Write([]byte(`{"ok":true,"ts":"1000.1"}`))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func loadIncident(c *C) *ycs.Incident {
+	data, err := os.ReadFile("../testdata/incident.json")
+	c.Assert(err, IsNil)
+
+	incident := &ycs.Incident{}
+	c.Assert(json.Unmarshal(data, incident), IsNil)
+
+	return incident
+}
@@ -0,0 +1,364 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// StateStore is storage for info about seen incidents
+type StateStore interface {
+	// Get returns state of incident with given ID or nil if incident wasn't seen
+	Get(id uint) (*IncidentState, error)
+
+	// Set saves state of incident with given ID
+	Set(id uint, state *IncidentState) error
+}
+
+// BatchStateStore is state store which can save states of many incidents at
+// once. MarkSeen uses it if store supports it.
+type BatchStateStore interface {
+	StateStore
+
+	// SetMany saves states of incidents with given IDs
+	SetMany(states map[uint]*IncidentState) error
+}
+
+// IncidentState contains info about seen incident
+type IncidentState struct {
+	UpdatedAt  time.Time `json:"updatedAt"`
+	CommentIDs []uint    `json:"commentIds"`
+}
+
+// IncidentUpdate contains info about new or changed incident
+type IncidentUpdate struct {
+	Incident *Incident
+	Comments Comments // Comments which weren't seen before
+	IsNew    bool     // Incident wasn't seen before
+}
+
+// MemoryStateStore is in-memory state store
+type MemoryStateStore struct {
+	data map[uint]*IncidentState
+	mu   sync.RWMutex
+}
+
+// FileStateStore is state store which keeps data in JSON file
+type FileStateStore struct {
+	file string
+	data map[uint]*IncidentState
+	mu   sync.RWMutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewMemoryStateStore creates new in-memory state store
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{data: map[uint]*IncidentState{}}
+}
+
+// NewFileStateStore creates new file state store and loads data from given
+// file if it exists
+func NewFileStateStore(file string) (*FileStateStore, error) {
+	s := &FileStateStore{file: file, data: map[uint]*IncidentState{}}
+
+	data, err := os.ReadFile(file)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return s, nil
+	case err != nil:
+		return nil, fmt.Errorf("Can't read state file: %w", err)
+	}
+
+	err = json.Unmarshal(data, &s.data)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode state file: %w", err)
+	}
+
+	return s, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// FindUpdates returns incidents which are new or changed since they were saved
+// in given store. Use MarkSeen to save incidents state after processing.
+func FindUpdates(store StateStore, incidents Incidents) ([]*IncidentUpdate, error) {
+	if store == nil {
+		return nil, fmt.Errorf("State store is nil")
+	}
+
+	var result []*IncidentUpdate
+
+	for _, i := range incidents {
+		if i == nil {
+			continue
+		}
+
+		state, err := store.Get(i.ID)
+
+		if err != nil {
+			return nil, fmt.Errorf("Can't get state of incident %d: %w", i.ID, err)
+		}
+
+		if state == nil {
+			result = append(result, &IncidentUpdate{Incident: i, Comments: i.Comments, IsNew: true})
+			continue
+		}
+
+		var newComments Comments
+
+		for _, c := range i.Comments {
+			if c != nil && !slices.Contains(state.CommentIDs, c.ID) {
+				newComments = append(newComments, c)
+			}
+		}
+
+		if len(newComments) != 0 || i.UpdatedAt.After(state.UpdatedAt) {
+			result = append(result, &IncidentUpdate{Incident: i, Comments: newComments})
+		}
+	}
+
+	return result, nil
+}
+
+// MarkSeen saves state of given incidents to store
+func MarkSeen(store StateStore, incidents ...*Incident) error {
+	if store == nil {
+		return fmt.Errorf("State store is nil")
+	}
+
+	states := map[uint]*IncidentState{}
+
+	for _, i := range incidents {
+		if i == nil {
+			continue
+		}
+
+		state := &IncidentState{UpdatedAt: i.UpdatedAt.Time}
+
+		for _, c := range i.Comments {
+			if c != nil {
+				state.CommentIDs = append(state.CommentIDs, c.ID)
+			}
+		}
+
+		states[i.ID] = state
+	}
+
+	if len(states) == 0 {
+		return nil
+	}
+
+	if batchStore, ok := store.(BatchStateStore); ok {
+		err := batchStore.SetMany(states)
+
+		if err != nil {
+			return fmt.Errorf("Can't save state of incidents: %w", err)
+		}
+
+		return nil
+	}
+
+	for id, state := range states {
+		err := store.Set(id, state)
+
+		if err != nil {
+			return fmt.Errorf("Can't save state of incident %d: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns state of incident with given ID
+func (s *MemoryStateStore) Get(id uint) (*IncidentState, error) {
+	if s == nil {
+		return nil, fmt.Errorf("State store is nil")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.data[id], nil
+}
+
+// Set saves state of incident with given ID
+func (s *MemoryStateStore) Set(id uint, state *IncidentState) error {
+	if s == nil {
+		return fmt.Errorf("State store is nil")
+	}
+
+	s.mu.Lock()
+	s.data[id] = state
+	s.mu.Unlock()
+
+	return nil
+}
+
+// SetMany saves states of incidents with given IDs
+func (s *MemoryStateStore) SetMany(states map[uint]*IncidentState) error {
+	if s == nil {
+		return fmt.Errorf("State store is nil")
+	}
+
+	s.mu.Lock()
+	maps.Copy(s.data, states)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Prune removes states of incidents updated before given date and returns
+// number of removed states
+func (s *MemoryStateStore) Prune(before time.Time) (int, error) {
+	if s == nil {
+		return 0, fmt.Errorf("State store is nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(pruneStates(s.data, before)), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns state of incident with given ID
+func (s *FileStateStore) Get(id uint) (*IncidentState, error) {
+	if s == nil {
+		return nil, fmt.Errorf("State store is nil")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.data[id], nil
+}
+
+// Set saves state of incident with given ID and writes all data to file
+func (s *FileStateStore) Set(id uint, state *IncidentState) error {
+	return s.SetMany(map[uint]*IncidentState{id: state})
+}
+
+// SetMany saves states of incidents with given IDs and writes all data to file
+// once
+func (s *FileStateStore) SetMany(states map[uint]*IncidentState) error {
+	if s == nil {
+		return fmt.Errorf("State store is nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := maps.Clone(s.data)
+	maps.Copy(s.data, states)
+
+	err := s.write()
+
+	if err != nil {
+		s.data = prev
+		return err
+	}
+
+	return nil
+}
+
+// Prune removes states of incidents updated before given date, writes all data
+// to file and returns number of removed states
+func (s *FileStateStore) Prune(before time.Time) (int, error) {
+	if s == nil {
+		return 0, fmt.Errorf("State store is nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := pruneStates(s.data, before)
+
+	if len(removed) == 0 {
+		return 0, nil
+	}
+
+	err := s.write()
+
+	if err != nil {
+		maps.Copy(s.data, removed)
+		return 0, err
+	}
+
+	return len(removed), nil
+}
+
+// write atomically writes data to file
+func (s *FileStateStore) write() error {
+	data, err := json.Marshal(s.data)
+
+	if err != nil {
+		return fmt.Errorf("Can't encode state: %w", err)
+	}
+
+	fd, err := os.CreateTemp(filepath.Dir(s.file), "."+filepath.Base(s.file)+".*")
+
+	if err != nil {
+		return fmt.Errorf("Can't create temporary state file: %w", err)
+	}
+
+	tmpFile := fd.Name()
+
+	_, err = fd.Write(data)
+
+	if err == nil {
+		err = fd.Sync()
+	}
+
+	if closeErr := fd.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmpFile, s.file)
+	}
+
+	if err != nil {
+		os.Remove(tmpFile)
+		return fmt.Errorf("Can't write state file: %w", err)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// pruneStates removes states of incidents updated before given date from map
+// and returns removed states
+func pruneStates(data map[uint]*IncidentState, before time.Time) map[uint]*IncidentState {
+	removed := map[uint]*IncidentState{}
+
+	for id, state := range data {
+		if state == nil || state.UpdatedAt.Before(before) {
+			removed[id] = state
+			delete(data, id)
+		}
+	}
+
+	return removed
+}
@@ -0,0 +1,179 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// StatsReport contains incidents statistics grouped by service, zone, region
+// and month
+type StatsReport struct {
+	Total    *Stats                           `json:"total"`
+	Services map[RegionCode]map[string]*Stats `json:"services"` // Region → service name → stats
+	Zones    map[string]*Stats                `json:"zones"`
+	Regions  map[RegionCode]*Stats            `json:"regions"`
+	Months   map[string]*Stats                `json:"months"` // Month in YYYY-MM format
+}
+
+// Stats contains statistics for group of incidents
+type Stats struct {
+	Count       int           `json:"count"`       // Number of incidents
+	Unavailable int           `json:"unavailable"` // Number of unavailable-level incidents
+	Resolved    int           `json:"resolved"`    // Number of incidents with end date
+	Reports     int           `json:"reports"`     // Number of incidents with published report
+	MTTR        time.Duration `json:"mttr"`        // Mean time to recovery (start → end)
+	MTTD        time.Duration `json:"mttd"`        // Mean time to publication (start → creation)
+	MeanReport  time.Duration `json:"meanReport"`  // Mean time to report publication (end → report)
+	MaxDuration time.Duration `json:"maxDuration"` // Duration of the longest incident
+
+	recoverySum    time.Duration
+	detectionSum   time.Duration
+	detectionCount int
+	reportSum      time.Duration
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Stats calculates incidents statistics. Services are grouped by regions the same
+// way as in Availability, so services with the same name in different regions
+// are counted separately.
+func (i Incidents) Stats() *StatsReport {
+	report := &StatsReport{
+		Total:    &Stats{},
+		Services: map[RegionCode]map[string]*Stats{},
+		Zones:    map[string]*Stats{},
+		Regions:  map[RegionCode]*Stats{},
+		Months:   map[string]*Stats{},
+	}
+
+	for _, ii := range i {
+		if ii == nil {
+			continue
+		}
+
+		report.Total.add(ii)
+
+		for _, l := range ii.serviceLocations() {
+			if report.Services[l.region] == nil {
+				report.Services[l.region] = map[string]*Stats{}
+			}
+
+			getStats(report.Services[l.region], l.name).add(ii)
+		}
+
+		for _, z := range uniqueStrings(ii.ZoneList()) {
+			getStats(report.Zones, z).add(ii)
+		}
+
+		for _, r := range uniqueStrings(ii.RegionList()) {
+			getStats(report.Regions, RegionCode(r)).add(ii)
+		}
+
+		if !ii.StartDate.IsZero() {
+			getStats(report.Months, ii.StartDate.UTC().Format("2006-01")).add(ii)
+		}
+	}
+
+	report.Total.calc()
+
+	for _, m := range []map[string]*Stats{report.Zones, report.Months} {
+		for _, s := range m {
+			s.calc()
+		}
+	}
+
+	for _, m := range report.Services {
+		for _, s := range m {
+			s.calc()
+		}
+	}
+
+	for _, s := range report.Regions {
+		s.calc()
+	}
+
+	return report
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Service returns stats for service with given name in given region. If service
+// wasn't affected by any incident, it returns empty stats.
+func (r *StatsReport) Service(name string, region RegionCode) *Stats {
+	if r == nil {
+		return nil
+	}
+
+	if r.Services[region][name] != nil {
+		return r.Services[region][name]
+	}
+
+	return &Stats{}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getStats returns stats for given key from map and creates it if required
+func getStats[K comparable](m map[K]*Stats, key K) *Stats {
+	if m[key] == nil {
+		m[key] = &Stats{}
+	}
+
+	return m[key]
+}
+
+// add adds incident info to stats
+func (s *Stats) add(i *Incident) {
+	s.Count++
+
+	if i.LevelID == LEVEL_ID_UNAVAILABLE {
+		s.Unavailable++
+	}
+
+	if !i.EndDate.IsZero() {
+		d := max(i.EndDate.Sub(i.StartDate.Time), 0)
+
+		s.Resolved++
+		s.recoverySum += d
+		s.MaxDuration = max(s.MaxDuration, d)
+	}
+
+	if !i.CreatedAt.IsZero() && !i.StartDate.IsZero() {
+		s.detectionCount++
+		s.detectionSum += max(i.CreatedAt.Sub(i.StartDate.Time), 0)
+	}
+
+	if !i.ReportPublishedTime.IsZero() {
+		since := i.EndDate.Time
+
+		if since.IsZero() {
+			since = i.StartDate.Time
+		}
+
+		s.Reports++
+		s.reportSum += max(i.ReportPublishedTime.Sub(since), 0)
+	}
+}
+
+// calc calculates mean values
+func (s *Stats) calc() {
+	if s.Resolved > 0 {
+		s.MTTR = s.recoverySum / time.Duration(s.Resolved)
+	}
+
+	if s.detectionCount > 0 {
+		s.MTTD = s.detectionSum / time.Duration(s.detectionCount)
+	}
+
+	if s.Reports > 0 {
+		s.MeanReport = s.reportSum / time.Duration(s.Reports)
+	}
+}
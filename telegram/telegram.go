@@ -0,0 +1,566 @@
+package telegram
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/essentialkaos/ycs"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// API_URL is default Telegram Bot API URL
+const API_URL = "https://api.telegram.org"
+
+// MAX_MESSAGE_SIZE is maximum size of message text
+const MAX_MESSAGE_SIZE = 4096
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ParseMode is message formatting mode
+type ParseMode string
+
+const (
+	PARSE_MODE_MARKDOWN_V2 ParseMode = "MarkdownV2"
+	PARSE_MODE_HTML        ParseMode = "HTML"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Config contains notifier configuration
+type Config struct {
+	// Token is bot token
+	Token string
+
+	// ChatID is chat ID or channel username (@channel)
+	ChatID string
+
+	// APIURL is Bot API URL (API_URL by default)
+	APIURL string
+
+	// ParseMode is message formatting mode (PARSE_MODE_MARKDOWN_V2 by default)
+	ParseMode ParseMode
+
+	// Lang is language of labels (ycs.LANG_EN by default)
+	Lang ycs.Lang
+
+	// HTTPClient is HTTP client used for sending requests (http.DefaultClient
+	// by default)
+	HTTPClient *http.Client
+}
+
+// Notifier sends incident events to Telegram chat
+type Notifier struct {
+	cfg      Config
+	messages map[uint]int64
+	mu       sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// apiResponse is Bot API response
+type apiResponse struct {
+	OK          bool        `json:"ok"`
+	Description string      `json:"description"`
+	Result      *apiMessage `json:"result"`
+}
+
+// apiMessage is sent message info
+type apiMessage struct {
+	MessageID int64 `json:"message_id"`
+}
+
+// replyParameters contains info about replied message
+type replyParameters struct {
+	MessageID                int64 `json:"message_id"`
+	AllowSendingWithoutReply bool  `json:"allow_sending_without_reply"`
+}
+
+// linkPreviewOptions contains link preview options
+type linkPreviewOptions struct {
+	IsDisabled bool `json:"is_disabled"`
+}
+
+// messageRequest is sendMessage and editMessageText request
+type messageRequest struct {
+	ChatID             string              `json:"chat_id"`
+	MessageID          int64               `json:"message_id,omitempty"`
+	Text               string              `json:"text"`
+	ParseMode          ParseMode           `json:"parse_mode"`
+	ReplyParameters    *replyParameters    `json:"reply_parameters,omitempty"`
+	LinkPreviewOptions *linkPreviewOptions `json:"link_preview_options,omitempty"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// mdTokenRegex is regex for Markdown elements produced by ycs converter
+var mdTokenRegex = regexp.MustCompile(
+	"```(?:[\\w+-]+\\n|\\n)?([\\s\\S]*?)```|`([^`\\n]+)`|\\*\\*([^*\\n]+)\\*\\*|\\*([^*\\n]+)\\*|!?\\[((?:\\\\.|[^\\]\\\\])*)\\]\\(([^)\\s]+)\\)|\\\\([!-/:-@\\[-`{-~])",
+)
+
+// mdEscapeRegex is regex for escaped characters
+var mdEscapeRegex = regexp.MustCompile("\\\\([!-/:-@\\[-`{-~])")
+
+// markdownV2Replacer escapes special characters in MarkdownV2 text
+var markdownV2Replacer = strings.NewReplacer(
+	`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+	"~", `\~`, "`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`,
+	"|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+)
+
+// markdownV2CodeReplacer escapes special characters in MarkdownV2 code blocks
+var markdownV2CodeReplacer = strings.NewReplacer(`\`, `\\`, "`", "\\`")
+
+// markdownV2URLReplacer escapes special characters in MarkdownV2 link URLs
+var markdownV2URLReplacer = strings.NewReplacer(`\`, `\\`, ")", `\)`)
+
+// htmlReplacer escapes special characters in HTML text
+var htmlReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewNotifier creates new Telegram notifier
+func NewNotifier(cfg Config) (*Notifier, error) {
+	switch {
+	case cfg.Token == "":
+		return nil, fmt.Errorf("Bot token must be set")
+	case cfg.ChatID == "":
+		return nil, fmt.Errorf("Chat ID must be set")
+	}
+
+	switch cfg.ParseMode {
+	case "":
+		cfg.ParseMode = PARSE_MODE_MARKDOWN_V2
+	case PARSE_MODE_MARKDOWN_V2, PARSE_MODE_HTML:
+		// ok
+	default:
+		return nil, fmt.Errorf("Unsupported parse mode %q", cfg.ParseMode)
+	}
+
+	if cfg.APIURL == "" {
+		cfg.APIURL = API_URL
+	}
+
+	if cfg.Lang == "" {
+		cfg.Lang = ycs.LANG_EN
+	}
+
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+
+	cfg.APIURL = strings.TrimRight(cfg.APIURL, "/")
+
+	return &Notifier{cfg: cfg, messages: map[uint]int64{}}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Notify sends message for given event. New incidents are posted as new
+// messages, new comments are posted as replies to the original message, and
+// resolved incidents, level changes and published reports edit the original
+// message. Events without incident are ignored.
+func (n *Notifier) Notify(ctx context.Context, e ycs.Event) error {
+	if n == nil {
+		return fmt.Errorf("Notifier is nil")
+	}
+
+	if e.Incident == nil {
+		return nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	id := e.Incident.ID
+	origID := n.messages[id]
+
+	switch e.Type {
+	case ycs.EVENT_INCIDENT_OPENED:
+		msgID, err := n.send(ctx, n.IncidentText(e.Incident), 0)
+
+		if err != nil {
+			return err
+		}
+
+		n.messages[id] = msgID
+
+	case ycs.EVENT_INCIDENT_UPDATED:
+		text := n.CommentsText(e.Incident, e.Comments)
+
+		if text == "" {
+			return nil
+		}
+
+		_, err := n.send(ctx, text, origID)
+
+		if err != nil {
+			return err
+		}
+
+	case ycs.EVENT_INCIDENT_RESOLVED, ycs.EVENT_LEVEL_CHANGED, ycs.EVENT_REPORT_PUBLISHED:
+		if origID != 0 {
+			return n.edit(ctx, origID, n.IncidentText(e.Incident))
+		}
+
+		msgID, err := n.send(ctx, n.IncidentText(e.Incident), 0)
+
+		if err != nil {
+			return err
+		}
+
+		n.messages[id] = msgID
+	}
+
+	return nil
+}
+
+// SetMessage sets ID of the original message for given incident
+func (n *Notifier) SetMessage(incidentID uint, messageID int64) {
+	if n == nil {
+		return
+	}
+
+	n.mu.Lock()
+	n.messages[incidentID] = messageID
+	n.mu.Unlock()
+}
+
+// Message returns ID of the original message for given incident
+func (n *Notifier) Message(incidentID uint) int64 {
+	if n == nil {
+		return 0
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.messages[incidentID]
+}
+
+// IncidentText returns formatted text with incident info
+func (n *Notifier) IncidentText(i *ycs.Incident) string {
+	if n == nil || i == nil {
+		return ""
+	}
+
+	mode, lang := n.cfg.ParseMode, n.cfg.Lang
+
+	var icon string
+
+	switch {
+	case i.IsResolved():
+		icon = "✅"
+	case i.LevelID == ycs.LEVEL_ID_UNAVAILABLE:
+		icon = "🔴"
+	default:
+		icon = "🟡"
+	}
+
+	var buf strings.Builder
+
+	buf.WriteString(Bold(icon+" "+i.Title, mode) + "\n\n")
+	buf.WriteString(field("Level", i.LevelID.Label(lang), mode))
+	buf.WriteString(field("Status", i.Status.Label(lang), mode))
+	buf.WriteString(field("Regions", formatList(i.RegionList()), mode))
+	buf.WriteString(field("Zones", formatList(i.ZoneList()), mode))
+	buf.WriteString(field("Services", formatList(i.ServiceList()), mode))
+
+	var body string
+
+	if i.IsReportPublished && i.Report != "" {
+		body = Convert(i.ReportMarkdown(), mode)
+	} else if comment := i.Comments.Latest(); comment != nil {
+		body = Convert(comment.Markdown(), mode)
+	}
+
+	link := Link(fmt.Sprintf("Incident #%d", i.ID), i.URL(lang), mode)
+
+	// Cutting formatted text can break entities, so we omit too long body
+	switch {
+	case body == "":
+		buf.WriteString("\n" + link)
+	case runeCount(buf.String(), body, link) > MAX_MESSAGE_SIZE-4:
+		buf.WriteString("\n" + Italic("Full text is available on status page", mode) + "\n\n" + link)
+	default:
+		buf.WriteString("\n" + body + "\n\n" + link)
+	}
+
+	return buf.String()
+}
+
+// CommentsText returns formatted text with given incident comments
+func (n *Notifier) CommentsText(i *ycs.Incident, comments ycs.Comments) string {
+	if n == nil || i == nil {
+		return ""
+	}
+
+	var parts []string
+
+	for _, c := range comments {
+		if c == nil || c.Content == "" {
+			continue
+		}
+
+		parts = append(parts, Convert(c.Markdown(), n.cfg.ParseMode))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	text := Bold("🔄 "+i.Title, n.cfg.ParseMode) + "\n\n" + strings.Join(parts, "\n\n")
+
+	return limitText(text, i.URL(n.cfg.Lang), n.cfg.ParseMode)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Convert converts Markdown produced by Comment.Markdown and
+// Incident.ReportMarkdown to Telegram MarkdownV2 or HTML
+func Convert(text string, mode ParseMode) string {
+	var buf strings.Builder
+
+	last := 0
+
+	for _, m := range mdTokenRegex.FindAllStringSubmatchIndex(text, -1) {
+		buf.WriteString(Escape(text[last:m[0]], mode))
+		last = m[1]
+
+		group := func(n int) string {
+			if m[n*2] < 0 {
+				return ""
+			}
+
+			return text[m[n*2]:m[n*2+1]]
+		}
+
+		switch {
+		case m[2] >= 0:
+			buf.WriteString(Pre(group(1), mode))
+		case m[4] >= 0:
+			buf.WriteString(Code(group(2), mode))
+		case m[6] >= 0:
+			buf.WriteString(Bold(group(3), mode))
+		case m[8] >= 0:
+			buf.WriteString(Italic(group(4), mode))
+		case m[14] >= 0:
+			buf.WriteString(Escape(group(7), mode))
+		default:
+			title := mdEscapeRegex.ReplaceAllString(group(5), "$1")
+
+			if title == "" {
+				title = group(6)
+			}
+
+			buf.WriteString(Link(title, group(6), mode))
+		}
+	}
+
+	buf.WriteString(Escape(text[last:], mode))
+
+	return strings.TrimSpace(buf.String())
+}
+
+// Escape escapes special characters in text
+func Escape(text string, mode ParseMode) string {
+	if mode == PARSE_MODE_HTML {
+		return htmlReplacer.Replace(text)
+	}
+
+	return markdownV2Replacer.Replace(text)
+}
+
+// Bold returns bold text
+func Bold(text string, mode ParseMode) string {
+	if mode == PARSE_MODE_HTML {
+		return "<b>" + Escape(text, mode) + "</b>"
+	}
+
+	return "*" + Escape(text, mode) + "*"
+}
+
+// Italic returns italic text
+func Italic(text string, mode ParseMode) string {
+	if mode == PARSE_MODE_HTML {
+		return "<i>" + Escape(text, mode) + "</i>"
+	}
+
+	return "_" + Escape(text, mode) + "_"
+}
+
+// Code returns inline code
+func Code(text string, mode ParseMode) string {
+	if mode == PARSE_MODE_HTML {
+		return "<code>" + Escape(text, mode) + "</code>"
+	}
+
+	return "`" + markdownV2CodeReplacer.Replace(text) + "`"
+}
+
+// Pre returns code block
+func Pre(text string, mode ParseMode) string {
+	text = strings.TrimRight(text, "\n")
+
+	if mode == PARSE_MODE_HTML {
+		return "<pre>" + Escape(text, mode) + "</pre>"
+	}
+
+	return "```\n" + markdownV2CodeReplacer.Replace(text) + "\n```"
+}
+
+// Link returns link with given title
+func Link(title, url string, mode ParseMode) string {
+	if mode == PARSE_MODE_HTML {
+		return `<a href="` + Escape(url, mode) + `">` + Escape(title, mode) + "</a>"
+	}
+
+	return "[" + Escape(title, mode) + "](" + markdownV2URLReplacer.Replace(url) + ")"
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// send sends new message and returns its ID
+func (n *Notifier) send(ctx context.Context, text string, replyTo int64) (int64, error) {
+	req := &messageRequest{
+		ChatID:             n.cfg.ChatID,
+		Text:               text,
+		ParseMode:          n.cfg.ParseMode,
+		LinkPreviewOptions: &linkPreviewOptions{IsDisabled: true},
+	}
+
+	if replyTo != 0 {
+		req.ReplyParameters = &replyParameters{
+			MessageID:                replyTo,
+			AllowSendingWithoutReply: true,
+		}
+	}
+
+	resp, err := n.call(ctx, "sendMessage", req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.Result == nil {
+		return 0, fmt.Errorf("API response doesn't contain message info")
+	}
+
+	return resp.Result.MessageID, nil
+}
+
+// edit edits text of message with given ID
+func (n *Notifier) edit(ctx context.Context, messageID int64, text string) error {
+	_, err := n.call(ctx, "editMessageText", &messageRequest{
+		ChatID:             n.cfg.ChatID,
+		MessageID:          messageID,
+		Text:               text,
+		ParseMode:          n.cfg.ParseMode,
+		LinkPreviewOptions: &linkPreviewOptions{IsDisabled: true},
+	})
+
+	// Editing message without changes is not an error for us
+	if err != nil && strings.Contains(err.Error(), "message is not modified") {
+		return nil
+	}
+
+	return err
+}
+
+// call calls Bot API method
+func (n *Notifier) call(ctx context.Context, method string, data any) (*apiResponse, error) {
+	body, err := json.Marshal(data)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't encode request: %w", err)
+	}
+
+	url := n.cfg.APIURL + "/bot" + n.cfg.Token + "/" + method
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("User-Agent", ycs.UA)
+
+	resp, err := n.cfg.HTTPClient.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't send request to %s: %w", method, redact(err, n.cfg.Token))
+	}
+
+	defer resp.Body.Close()
+
+	apiResp := &apiResponse{}
+	err = json.NewDecoder(io.LimitReader(resp.Body, 1024*1024)).Decode(apiResp)
+
+	switch {
+	case err != nil && resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("API returned non-ok status code %d", resp.StatusCode)
+	case err != nil:
+		return nil, fmt.Errorf("Can't decode API response: %w", err)
+	case !apiResp.OK:
+		return nil, fmt.Errorf("API returned error: %s", apiResp.Description)
+	}
+
+	return apiResp, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// field returns formatted field with given name and value
+func field(name, value string, mode ParseMode) string {
+	return Bold(name+":", mode) + " " + Escape(value, mode) + "\n"
+}
+
+// formatList formats slice as comma-separated list
+func formatList(items []string) string {
+	if len(items) == 0 {
+		return "—"
+	}
+
+	return strings.Join(items, ", ")
+}
+
+// limitText replaces too long text with short message with link to incident
+func limitText(text, url string, mode ParseMode) string {
+	if runeCount(text) <= MAX_MESSAGE_SIZE {
+		return text
+	}
+
+	// Cutting formatted text can break entities, so we send only link instead
+	return Escape("Message is too long, see details on status page: ", mode) + Link(url, url, mode)
+}
+
+// runeCount returns total number of characters in given strings
+func runeCount(texts ...string) int {
+	var result int
+
+	for _, t := range texts {
+		result += utf8.RuneCountInString(t)
+	}
+
+	return result
+}
+
+// redact removes bot token from error text
+func redact(err error, token string) error {
+	return fmt.Errorf("%s", strings.ReplaceAll(err.Error(), token, "<token>"))
+}
@@ -0,0 +1,234 @@
+package telegram
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/essentialkaos/ycs"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type TelegramSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&TelegramSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// botStub is a local stand-in for Bot API
+type botStub struct {
+	methods  []string
+	requests []*messageRequest
+	lastID   int64
+	mu       sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *TelegramSuite) TestNotifierErrors(c *C) {
+	_, err := NewNotifier(Config{})
+	c.Assert(err, NotNil)
+
+	_, err = NewNotifier(Config{Token: "123:ABC"})
+	c.Assert(err, NotNil)
+
+	_, err = NewNotifier(Config{Token: "123:ABC", ChatID: "1", ParseMode: "Markdown"})
+	c.Assert(err, NotNil)
+
+	var n *Notifier
+
+	c.Assert(n.Notify(context.Background(), ycs.Event{}), NotNil)
+	c.Assert(n.IncidentText(nil), Equals, "")
+	c.Assert(n.CommentsText(nil, nil), Equals, "")
+	c.Assert(n.Message(1), Equals, int64(0))
+	n.SetMessage(1, 1)
+
+	stub := &botStub{}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	incident := loadIncident(c)
+	ev := ycs.Event{Type: ycs.EVENT_INCIDENT_OPENED, Incident: incident}
+
+	n, err = NewNotifier(Config{Token: "123:BAD", ChatID: "1", APIURL: server.URL})
+	c.Assert(err, IsNil)
+	c.Assert(n.Notify(context.Background(), ev), ErrorMatches, "API returned error: Unauthorized")
+	c.Assert(n.Notify(context.Background(), ycs.Event{Type: ycs.EVENT_ERROR}), IsNil)
+
+	n, err = NewNotifier(Config{Token: "123:ABC", ChatID: "1", APIURL: server.URL + "/broken"})
+	c.Assert(err, IsNil)
+	c.Assert(n.Notify(context.Background(), ev), ErrorMatches, "API returned non-ok status code 404")
+
+	n, err = NewNotifier(Config{Token: "123:SECRET", ChatID: "1", APIURL: "http://127.0.0.1:1"})
+	c.Assert(err, IsNil)
+
+	err = n.Notify(context.Background(), ev)
+	c.Assert(err, NotNil)
+	c.Assert(strings.Contains(err.Error(), "SECRET"), Equals, false)
+}
+
+func (s *TelegramSuite) TestNotifier(c *C) {
+	stub := &botStub{}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	n, err := NewNotifier(Config{Token: "123:ABC", ChatID: "@status", APIURL: server.URL + "/"})
+	c.Assert(err, IsNil)
+
+	incident := loadIncident(c)
+	ctx := context.Background()
+
+	c.Assert(n.Notify(ctx, ycs.Event{Type: ycs.EVENT_INCIDENT_OPENED, Incident: incident}), IsNil)
+	c.Assert(n.Message(incident.ID), Equals, int64(1))
+
+	c.Assert(n.Notify(ctx, ycs.Event{
+		Type: ycs.EVENT_INCIDENT_UPDATED, Incident: incident,
+		Comments: ycs.Comments{incident.Comments.Get(0)},
+	}), IsNil)
+	c.Assert(n.Notify(ctx, ycs.Event{Type: ycs.EVENT_INCIDENT_UPDATED, Incident: incident}), IsNil)
+	c.Assert(n.Notify(ctx, ycs.Event{Type: ycs.EVENT_INCIDENT_RESOLVED, Incident: incident}), IsNil)
+	c.Assert(n.Notify(ctx, ycs.Event{Type: ycs.EVENT_REPORT_PUBLISHED, Incident: incident}), IsNil)
+	c.Assert(n.Notify(ctx, ycs.Event{Type: ycs.EVENT_SERVICE_ADDED, Incident: incident}), IsNil)
+
+	c.Assert(stub.methods, DeepEquals, []string{
+		"sendMessage", "sendMessage", "editMessageText", "editMessageText",
+	})
+
+	c.Assert(stub.requests[0].ChatID, Equals, "@status")
+	c.Assert(stub.requests[0].ParseMode, Equals, PARSE_MODE_MARKDOWN_V2)
+	c.Assert(stub.requests[0].ReplyParameters, IsNil)
+	c.Assert(stub.requests[1].ReplyParameters, NotNil)
+	c.Assert(stub.requests[1].ReplyParameters.MessageID, Equals, int64(1))
+	c.Assert(stub.requests[2].MessageID, Equals, int64(1))
+	c.Assert(stub.requests[2].Text, Matches, `(?s)\*✅ .+`)
+
+	n.SetMessage(1, 10)
+	c.Assert(n.Notify(ctx, ycs.Event{Type: ycs.EVENT_INCIDENT_RESOLVED, Incident: &ycs.Incident{ID: 1}}), IsNil)
+	c.Assert(stub.requests[4].MessageID, Equals, int64(10))
+
+	c.Assert(n.Notify(ctx, ycs.Event{Type: ycs.EVENT_INCIDENT_RESOLVED, Incident: &ycs.Incident{ID: 2}}), IsNil)
+	c.Assert(stub.methods[5], Equals, "sendMessage")
+	c.Assert(n.Message(2), Equals, int64(3))
+}
+
+func (s *TelegramSuite) TestText(c *C) {
+	incident := loadIncident(c)
+
+	n, _ := NewNotifier(Config{Token: "123:ABC", ChatID: "1"})
+	text := n.IncidentText(incident)
+
+	c.Assert(strings.HasPrefix(text, "*✅ "), Equals, true)
+	c.Assert(text, Matches, `(?s).*\[Incident \\#972\]\(https://.+\)$`)
+
+	n, _ = NewNotifier(Config{Token: "123:ABC", ChatID: "1", ParseMode: PARSE_MODE_HTML})
+	text = n.IncidentText(incident)
+
+	c.Assert(strings.HasPrefix(text, "<b>✅ "), Equals, true)
+	c.Assert(text, Matches, `(?s).*<a href="https://.+">Incident #972</a>$`)
+
+	c.Assert(n.CommentsText(incident, ycs.Comments{{}}), Equals, "")
+
+	long := &ycs.Incident{ID: 1, Comments: ycs.Comments{{Content: strings.Repeat("a", MAX_MESSAGE_SIZE)}}}
+	c.Assert(n.IncidentText(long), Matches, `(?s).*<i>Full text is available on status page</i>.*`)
+	c.Assert(n.CommentsText(long, long.Comments), Matches, `Message is too long.*`)
+}
+
+func (s *TelegramSuite) TestConvert(c *C) {
+	c.Assert(Escape("1.5-2 (test) _a_ *b* [c] ~d~ `e` >f #g +h =i |j {k} !l \\", PARSE_MODE_MARKDOWN_V2),
+		Equals, `1\.5\-2 \(test\) \_a\_ \*b\* \[c\] \~d\~ `+"\\`e\\`"+` \>f \#g \+h \=i \|j \{k\} \!l \\`)
+	c.Assert(Escape(`<a href="x">&</a>`, PARSE_MODE_HTML), Equals, `&lt;a href=&quot;x&quot;&gt;&amp;&lt;/a&gt;`)
+
+	md := "**Bold.** and *it-alic* • item\n1. [Link (1)](https://domain.com/a_b) ![IMG](https://domain.com/i.png)\n`a\\b` ```\nx_1 `y`\n```"
+
+	c.Assert(Convert(md, PARSE_MODE_MARKDOWN_V2), Equals,
+		"*Bold\\.* and _it\\-alic_ • item\n1\\. [Link \\(1\\)](https://domain.com/a_b) [IMG](https://domain.com/i.png)\n`a\\\\b` ```\nx_1 \\`y\\`\n```",
+	)
+
+	c.Assert(Convert(md, PARSE_MODE_HTML), Equals,
+		"<b>Bold.</b> and <i>it-alic</i> • item\n1. <a href=\"https://domain.com/a_b\">Link (1)</a> <a href=\"https://domain.com/i.png\">IMG</a>\n<code>a\\b</code> <pre>x_1 `y`</pre>",
+	)
+
+	c.Assert(Link("a", `https://domain.com/a_(b)\`, PARSE_MODE_MARKDOWN_V2), Equals, `[a](https://domain.com/a_(b\)\\)`)
+	c.Assert(Convert("[](https://domain.com) ![](https://domain.com/i.png) <tag>", PARSE_MODE_HTML), Equals,
+		`<a href="https://domain.com">https://domain.com</a> <a href="https://domain.com/i.png">https://domain.com/i.png</a> &lt;tag&gt;`)
+	c.Assert(Convert(`2 \* 3, snake\_case, ![Chart \[1\]](https://domain.com/i.png)`, PARSE_MODE_HTML), Equals,
+		`2 * 3, snake_case, <a href="https://domain.com/i.png">Chart [1]</a>`)
+	c.Assert(Convert(`1\. a\_b`, PARSE_MODE_MARKDOWN_V2), Equals, `1\. a\_b`)
+	c.Assert(Convert("```go\nx := 1\n```", PARSE_MODE_HTML), Equals, "<pre>x := 1</pre>")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *botStub) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	method, ok := strings.CutPrefix(r.URL.Path, "/bot123:ABC/")
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/bot123:BAD/"):
+		rw.WriteHeader(401)
+		rw.Write([]byte(`{"ok":false,"error_code":401,"description":"Unauthorized"}`))
+		return
+	case !ok:
+		rw.WriteHeader(404)
+		rw.Write([]byte(`Not Found`))
+		return
+	}
+
+	req := &messageRequest{}
+	json.NewDecoder(r.Body).Decode(req)
+
+	s.methods = append(s.methods, method)
+	s.requests = append(s.requests, req)
+
+	if method == "editMessageText" {
+		if len(s.requests) > 1 && s.requests[len(s.requests)-2].Text == req.Text {
+			rw.WriteHeader(400)
+			rw.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: message is not modified"}`))
+			return
+		}
+
+		rw.Write([]byte(`{"ok":true,"result":{"message_id":` + jsonInt(req.MessageID) + `}}`))
+		return
+	}
+
+	s.lastID++
+	rw.Write([]byte(`{"ok":true,"result":{"message_id":` + jsonInt(s.lastID) + `}}`))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func loadIncident(c *C) *ycs.Incident {
+	data, err := os.ReadFile("../testdata/incident.json")
+	c.Assert(err, IsNil)
+
+	incident := &ycs.Incident{}
+	c.Assert(json.Unmarshal(data, incident), IsNil)
+
+	return incident
+}
+
+func jsonInt(v int64) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
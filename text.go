@@ -0,0 +1,381 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/strutil"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	ansiBold      = "\x1b[1m"
+	ansiBoldOff   = "\x1b[22m"
+	ansiItalic    = "\x1b[3m"
+	ansiItalicOff = "\x1b[23m"
+	ansiUnderline = "\x1b[4m"
+	ansiUnderOff  = "\x1b[24m"
+	ansiCyan      = "\x1b[36m"
+	ansiYellow    = "\x1b[33m"
+	ansiGray      = "\x1b[90m"
+	ansiColorOff  = "\x1b[39m"
+)
+
+// textRuleSize is size of horizontal rule if width is not set
+const textRuleSize = 40
+
+// textCodeSpace is placeholder for whitespace in inline code. It keeps code
+// spans from being split or collapsed while text is wrapped.
+const textCodeSpace = "\x1f"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// textRenderer renders HTML as plain text or text with ANSI styles
+type textRenderer struct {
+	ansi  bool
+	links []string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ansiRegex matches ANSI SGR sequences
+var ansiRegex = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// textCodeSpaceReplacer replaces whitespace in inline code with placeholders
+var textCodeSpaceReplacer = strings.NewReplacer(
+	" ", textCodeSpace, "\t", textCodeSpace, "\n", textCodeSpace,
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// htmlToText converts HTML to text wrapped to given width. If ansi is true,
+// ANSI styles are used for headings and emphasis.
+func htmlToText(text string, width int, ansi bool) string {
+	if strings.TrimSpace(text) == "" {
+		return ""
+	}
+
+	nodes, err := html.ParseFragment(strings.NewReader(text), mdBodyNode)
+
+	if err != nil {
+		return strings.TrimSpace(text)
+	}
+
+	r := &textRenderer{ansi: ansi}
+	result := joinMDBlocks(r.walker().blocks(nodes, width), "\n\n")
+	result = strings.ReplaceAll(result, textCodeSpace, " ")
+
+	if len(r.links) == 0 {
+		return result
+	}
+
+	var footnotes []string
+
+	for i, link := range r.links {
+		footnotes = append(footnotes, r.style(fmt.Sprintf("[%d]", i+1), ansiGray, ansiColorOff)+" "+link)
+	}
+
+	return strings.TrimLeft(result+"\n\n"+strings.Join(footnotes, "\n"), "\n")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// walker returns HTML walker which uses renderer callbacks
+func (r *textRenderer) walker() *mdWalker {
+	return &mdWalker{
+		inline:    r.inline,
+		paragraph: r.paragraph,
+		heading:   r.heading,
+		code:      r.code,
+		quote:     r.quote,
+		rule:      r.rule,
+		table:     r.table,
+		marker:    r.marker,
+	}
+}
+
+// paragraph renders paragraph wrapped to given width
+func (r *textRenderer) paragraph(text string, width int) string {
+	return strings.Join(wrapText(text, width), "\n")
+}
+
+// heading renders heading wrapped to given width
+func (r *textRenderer) heading(_ int, text string, width int) string {
+	text = strings.Join(strings.Fields(text), " ")
+
+	if text == "" {
+		return ""
+	}
+
+	text = r.style(r.style(text, ansiBold, ansiBoldOff), ansiCyan, ansiColorOff)
+
+	return strings.Join(wrapText(text, width), "\n")
+}
+
+// marker returns marker of list item
+func (r *textRenderer) marker(ordered bool, index int) string {
+	if ordered {
+		return strconv.Itoa(index) + ". "
+	}
+
+	return strutil.B(r.ansi, "• ", "- ")
+}
+
+// code renders preformatted text
+func (r *textRenderer) code(n *html.Node) string {
+	code := strings.TrimRight(htmlText(n), "\n")
+	code = strings.TrimPrefix(code, "\n")
+	lines := strings.Split(code, "\n")
+
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "    " + strutil.B(r.ansi, ansiYellow+line+ansiColorOff, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// quote renders block quote
+func (r *textRenderer) quote(text string) string {
+	bar := strutil.B(r.ansi, ansiGray+"│"+ansiColorOff, ">")
+	return prefixLines(text, bar+" ", bar)
+}
+
+// rule renders horizontal rule
+func (r *textRenderer) rule(width int) string {
+	size := textRuleSize
+
+	if width > 0 {
+		size = width
+	}
+
+	return strings.Repeat(strutil.B(r.ansi, "─", "-"), size)
+}
+
+// table renders table with aligned columns
+func (r *textRenderer) table(rows [][]string) string {
+	for _, row := range rows {
+		for i, cell := range row {
+			row[i] = strings.Join(strings.Fields(cell), " ")
+		}
+	}
+
+	var sizes []int
+
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(sizes) {
+				sizes = append(sizes, 0)
+			}
+
+			sizes[i] = max(sizes[i], visualLen(cell))
+		}
+	}
+
+	if len(sizes) == 0 {
+		return ""
+	}
+
+	var lines []string
+
+	for i, row := range rows {
+		var cells []string
+
+		for j, size := range sizes {
+			var cell string
+
+			if j < len(row) {
+				cell = row[j]
+			}
+
+			if i == 0 {
+				cell = r.style(cell, ansiBold, ansiBoldOff)
+			}
+
+			cells = append(cells, cell+strings.Repeat(" ", size-visualLen(cell)))
+		}
+
+		lines = append(lines, strings.TrimRight(strings.Join(cells, "  "), " "))
+
+		if i == 0 {
+			var rule []string
+
+			for _, size := range sizes {
+				rule = append(rule, strings.Repeat(strutil.B(r.ansi, "─", "-"), size))
+			}
+
+			lines = append(lines, strings.Join(rule, "  "))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// inline renders inline node. Line breaks are represented as new lines.
+func (r *textRenderer) inline(w *mdWalker, n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return mdSpaceRegex.ReplaceAllString(n.Data, " ")
+	case html.ElementNode:
+		// continue
+	default:
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		return "\n"
+
+	case atom.B, atom.Strong:
+		return r.style(w.inlineChildren(n), ansiBold, ansiBoldOff)
+
+	case atom.I, atom.Em:
+		return r.style(w.inlineChildren(n), ansiItalic, ansiItalicOff)
+
+	case atom.Code, atom.Kbd, atom.Samp, atom.Tt:
+		return r.style(textCodeSpaceReplacer.Replace(htmlText(n)), ansiYellow, ansiColorOff)
+
+	case atom.A:
+		text := strings.TrimSpace(w.inlineChildren(n))
+		href := strings.TrimSpace(htmlAttr(n, "href"))
+		plain := ansiRegex.ReplaceAllString(text, "")
+
+		switch {
+		case href == "":
+			return text
+		case text == "", plain == href:
+			return href
+		case "mailto:"+plain == href:
+			return text
+		}
+
+		return r.style(text, ansiUnderline, ansiUnderOff) + r.footnote(href)
+
+	case atom.Img:
+		src := strings.TrimSpace(htmlAttr(n, "src"))
+
+		if src == "" {
+			return ""
+		}
+
+		alt := strings.Join(strings.Fields(htmlAttr(n, "alt")), " ")
+
+		if alt == "" {
+			return "[image]" + r.footnote(src)
+		}
+
+		return "[image: " + alt + "]" + r.footnote(src)
+
+	case atom.Script, atom.Style:
+		return ""
+	}
+
+	return w.inlineChildren(n)
+}
+
+// footnote adds link to footnotes and returns footnote marker
+func (r *textRenderer) footnote(link string) string {
+	index := slices.Index(r.links, link)
+
+	if index == -1 {
+		r.links = append(r.links, link)
+		index = len(r.links) - 1
+	}
+
+	return r.style(fmt.Sprintf("[%d]", index+1), ansiGray, ansiColorOff)
+}
+
+// style applies ANSI style to every word of text, so text can be wrapped
+// without breaking styles
+func (r *textRenderer) style(text, on, off string) string {
+	if !r.ansi || strings.TrimSpace(text) == "" {
+		return text
+	}
+
+	var buf strings.Builder
+	var word strings.Builder
+
+	flush := func() {
+		if word.Len() != 0 {
+			buf.WriteString(on + word.String() + off)
+			word.Reset()
+		}
+	}
+
+	for _, c := range text {
+		if c == ' ' || c == '\n' {
+			flush()
+			buf.WriteRune(c)
+		} else {
+			word.WriteRune(c)
+		}
+	}
+
+	flush()
+
+	return buf.String()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// wrapText splits text into lines with given maximum width. Words longer
+// than width are not split.
+func wrapText(text string, width int) []string {
+	var result []string
+
+	for line := range strings.SplitSeq(strings.TrimSpace(text), "\n") {
+		words := strings.Fields(line)
+
+		if width <= 0 {
+			result = append(result, strings.Join(words, " "))
+			continue
+		}
+
+		var buf strings.Builder
+		var size int
+
+		for _, w := range words {
+			wordSize := visualLen(w)
+
+			if size > 0 && size+1+wordSize > width {
+				result = append(result, buf.String())
+				buf.Reset()
+				size = 0
+			}
+
+			if size > 0 {
+				buf.WriteByte(' ')
+				size++
+			}
+
+			buf.WriteString(w)
+			size += wordSize
+		}
+
+		result = append(result, buf.String())
+	}
+
+	return result
+}
+
+// visualLen returns visual length of text without ANSI sequences
+func visualLen(text string) int {
+	return strutil.LenVisual(ansiRegex.ReplaceAllString(text, ""))
+}
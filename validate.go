@@ -0,0 +1,96 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ValidationError is error returned if request contains invalid field
+type ValidationError struct {
+	Field   string // Request field name
+	Message string // Error description
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrInvalidRequest is returned if request validation failed
+var ErrInvalidRequest = fmt.Errorf("Invalid request")
+
+// zoneRegions contains regions of availability zones
+var zoneRegions = map[string]RegionCode{
+	ZONE_RU_A: REGION_RU,
+	ZONE_RU_B: REGION_RU,
+	ZONE_RU_C: REGION_RU,
+	ZONE_RU_D: REGION_RU,
+	ZONE_KZ_A: REGION_KZ,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ZoneRegion returns region of given availability zone
+func ZoneRegion(zone string) RegionCode {
+	return zoneRegions[zone]
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate validates incidents request
+func (r IncidentsRequest) Validate() error {
+	switch {
+	case r.Lang != "" && !r.Lang.IsValid():
+		return &ValidationError{"Lang", fmt.Sprintf("unsupported language %q", r.Lang)}
+
+	case r.Region != "" && !r.Region.IsValid():
+		return &ValidationError{"Region", fmt.Sprintf("unknown region %q", r.Region)}
+
+	case r.Status != "" && !r.Status.IsValid():
+		return &ValidationError{"Status", fmt.Sprintf("unknown status %q", r.Status)}
+
+	case !r.From.IsZero() && !r.To.IsZero() && r.From.After(r.To):
+		return &ValidationError{"From", fmt.Sprintf(
+			"start date (%s) is after end date (%s)",
+			r.From.Format("2006-01-02"), r.To.Format("2006-01-02"),
+		)}
+
+	case r.Page < 0:
+		return &ValidationError{"Page", fmt.Sprintf("page number can't be negative (%d)", r.Page)}
+
+	case r.PageSize < 0:
+		return &ValidationError{"PageSize", fmt.Sprintf("page size can't be negative (%d)", r.PageSize)}
+	}
+
+	for _, zone := range r.Zones {
+		if !slices.Contains(AllZones, zone) {
+			return &ValidationError{"Zones", fmt.Sprintf("unknown zone %q", zone)}
+		}
+
+		if r.Region != "" && r.Region != REGION_ALL && ZoneRegion(zone) != r.Region {
+			return &ValidationError{"Zones", fmt.Sprintf(
+				"zone %q doesn't belong to region %q", zone, r.Region,
+			)}
+		}
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Error returns error message
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("Invalid field %s: %s", e.Field, e.Message)
+}
+
+// Is returns true if error matches given sentinel error
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidRequest
+}
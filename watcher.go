@@ -0,0 +1,361 @@
+package ycs
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// EventType is type of status change event
+type EventType string
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	EVENT_INCIDENT_OPENED   EventType = "incident_opened"
+	EVENT_INCIDENT_UPDATED  EventType = "incident_updated"
+	EVENT_INCIDENT_RESOLVED EventType = "incident_resolved"
+	EVENT_REPORT_PUBLISHED  EventType = "report_published"
+	EVENT_LEVEL_CHANGED     EventType = "level_changed"
+	EVENT_SERVICE_ADDED     EventType = "service_added"
+	EVENT_SERVICE_REMOVED   EventType = "service_removed"
+	EVENT_ERROR             EventType = "error"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Event contains info about status change
+type Event struct {
+	Type     EventType `json:"type"`
+	Time     time.Time `json:"time"`               // Time when change was detected
+	Incident *Incident `json:"incident,omitempty"` // Current incident state
+	Previous *Incident `json:"previous,omitempty"` // Previous incident state
+	Comments Comments  `json:"comments,omitempty"` // New comments
+	Service  *Service  `json:"service,omitempty"`  // Added or removed service
+	Err      error     `json:"-"`                  // Polling error
+}
+
+// Change contains info about changed incident field
+type Change struct {
+	Field string `json:"field"` // Field name (as in JSON)
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// WatcherOptions contains watcher options
+type WatcherOptions struct {
+	Interval    time.Duration    // Polling interval (1 minute by default)
+	Request     IncidentsRequest // Incidents request
+	Services    bool             // Watch for added and removed services
+	EmitInitial bool             // Emit events for all incidents found on the first poll
+}
+
+// Watcher polls API and emits events when status changes
+type Watcher struct {
+	client *Client
+	opts   WatcherOptions
+
+	incidents Incidents
+	services  Services
+
+	polled         bool
+	servicesPolled bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewWatcher creates new status watcher. If client is nil, default client is
+// used. All requests are sent through the client, so client rate limits and
+// retry policy are applied to polling.
+func NewWatcher(client *Client, opts WatcherOptions) *Watcher {
+	if client == nil {
+		client = defaultClient
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = time.Minute
+	}
+
+	return &Watcher{client: client, opts: opts}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Run polls API until context is cancelled and calls handler for every event
+func (w *Watcher) Run(ctx context.Context, handler func(e Event)) error {
+	if w == nil {
+		return fmt.Errorf("Watcher is nil")
+	}
+
+	if handler == nil {
+		return fmt.Errorf("Event handler is nil")
+	}
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		for _, e := range w.Poll(ctx) {
+			if e.Type == EVENT_ERROR && ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			handler(e)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Watch starts polling in background and returns channel with events. Channel
+// is closed when context is cancelled.
+func (w *Watcher) Watch(ctx context.Context) <-chan Event {
+	ch := make(chan Event, 16)
+
+	go func() {
+		defer close(ch)
+
+		w.Run(ctx, func(e Event) {
+			select {
+			case ch <- e:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return ch
+}
+
+// Poll fetches current status once and returns events for all changes since
+// previous poll
+func (w *Watcher) Poll(ctx context.Context) []Event {
+	if w == nil {
+		return nil
+	}
+
+	var events []Event
+
+	incidents, err := w.client.GetIncidentsContext(ctx, w.opts.Request)
+
+	if err != nil {
+		return []Event{{Type: EVENT_ERROR, Time: time.Now(), Err: err}}
+	}
+
+	if w.polled || w.opts.EmitInitial {
+		events = append(events, DiffIncidents(w.incidents, incidents)...)
+	}
+
+	// Incidents state is saved before fetching services, so the same events
+	// are not emitted again if services request fails
+	w.incidents = incidents
+	w.polled = true
+
+	if !w.opts.Services {
+		return events
+	}
+
+	services, err := w.client.GetServicesContext(ctx, w.opts.Request.Lang)
+
+	if err != nil {
+		return append(events, Event{Type: EVENT_ERROR, Time: time.Now(), Err: err})
+	}
+
+	if w.servicesPolled || w.opts.EmitInitial {
+		events = append(events, DiffServices(w.services, services)...)
+	}
+
+	w.services = services
+	w.servicesPolled = true
+
+	return events
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DiffIncidents compares two snapshots of incidents and returns events for all
+// changes. Incidents missing in the current snapshot are ignored.
+func DiffIncidents(prev, cur Incidents) []Event {
+	var events []Event
+
+	now := time.Now()
+	prevIndex := map[uint]*Incident{}
+
+	for _, i := range prev {
+		if i != nil {
+			prevIndex[i.ID] = i
+		}
+	}
+
+	for _, i := range cur {
+		if i == nil {
+			continue
+		}
+
+		p := prevIndex[i.ID]
+
+		if p == nil {
+			events = append(events, Event{
+				Type: EVENT_INCIDENT_OPENED, Time: now,
+				Incident: i, Comments: i.Comments,
+			})
+
+			if i.IsResolved() {
+				events = append(events, Event{Type: EVENT_INCIDENT_RESOLVED, Time: now, Incident: i})
+			}
+
+			if i.IsReportPublished {
+				events = append(events, Event{Type: EVENT_REPORT_PUBLISHED, Time: now, Incident: i})
+			}
+
+			continue
+		}
+
+		newComments := i.Comments.Since(p.Comments)
+
+		if len(newComments) != 0 {
+			events = append(events, Event{
+				Type: EVENT_INCIDENT_UPDATED, Time: now,
+				Incident: i, Previous: p, Comments: newComments,
+			})
+		}
+
+		if p.LevelID != i.LevelID {
+			events = append(events, Event{Type: EVENT_LEVEL_CHANGED, Time: now, Incident: i, Previous: p})
+		}
+
+		if !p.IsResolved() && i.IsResolved() {
+			events = append(events, Event{Type: EVENT_INCIDENT_RESOLVED, Time: now, Incident: i, Previous: p})
+		}
+
+		if !p.IsReportPublished && i.IsReportPublished {
+			events = append(events, Event{Type: EVENT_REPORT_PUBLISHED, Time: now, Incident: i, Previous: p})
+		}
+	}
+
+	return events
+}
+
+// DiffServices compares two snapshots of services and returns events for added
+// and removed services
+func DiffServices(prev, cur Services) []Event {
+	var events []Event
+
+	now := time.Now()
+	prevIndex := map[string]*Service{}
+	curIndex := map[string]*Service{}
+
+	for _, s := range prev {
+		if s != nil {
+			prevIndex[s.key()] = s
+		}
+	}
+
+	for _, s := range cur {
+		if s == nil {
+			continue
+		}
+
+		curIndex[s.key()] = s
+
+		if prevIndex[s.key()] == nil {
+			events = append(events, Event{Type: EVENT_SERVICE_ADDED, Time: now, Service: s})
+		}
+	}
+
+	for _, s := range prev {
+		if s != nil && curIndex[s.key()] == nil {
+			events = append(events, Event{Type: EVENT_SERVICE_REMOVED, Time: now, Service: s})
+		}
+	}
+
+	return events
+}
+
+// IncidentChanges returns changes of incident fields. If previous state is nil,
+// all fields are compared with empty incident.
+func IncidentChanges(prev, cur *Incident) []Change {
+	if cur == nil {
+		return nil
+	}
+
+	if prev == nil {
+		prev = &Incident{}
+	}
+
+	var changes []Change
+
+	addChange := func(field string, old, new any, changed bool) {
+		if changed {
+			changes = append(changes, Change{field, old, new})
+		}
+	}
+
+	addChange("title", prev.Title, cur.Title, prev.Title != cur.Title)
+	addChange("status", prev.Status, cur.Status, prev.Status != cur.Status)
+	addChange("levelId", prev.LevelID, cur.LevelID, prev.LevelID != cur.LevelID)
+	addChange("startDate", prev.StartDate, cur.StartDate, !prev.StartDate.Equal(cur.StartDate.Time))
+	addChange("endDate", prev.EndDate, cur.EndDate, !prev.EndDate.Equal(cur.EndDate.Time))
+	addChange(
+		"isReportPublished", prev.IsReportPublished, cur.IsReportPublished,
+		prev.IsReportPublished != cur.IsReportPublished,
+	)
+	addChange("report", prev.Report, cur.Report, prev.Report != cur.Report)
+
+	prevRegions, curRegions := prev.RegionList(), cur.RegionList()
+	addChange("installations", prevRegions, curRegions, !slices.Equal(prevRegions, curRegions))
+
+	prevZones, curZones := prev.ZoneList(), cur.ZoneList()
+	addChange("zones", prevZones, curZones, !slices.Equal(prevZones, curZones))
+
+	prevServices, curServices := prev.ServiceList(), cur.ServiceList()
+	addChange("services", prevServices, curServices, !slices.Equal(prevServices, curServices))
+
+	prevComments, curComments := prev.Comments.IDs(), cur.Comments.IDs()
+	addChange("comments", prevComments, curComments, !slices.Equal(prevComments, curComments))
+
+	return changes
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Since returns comments which are not present in given slice
+func (c Comments) Since(prev Comments) Comments {
+	var result Comments
+
+	known := map[uint]bool{}
+
+	for _, cc := range prev {
+		if cc != nil {
+			known[cc.ID] = true
+		}
+	}
+
+	for _, cc := range c {
+		if cc != nil && !known[cc.ID] {
+			result = append(result, cc)
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// key returns unique service key (the same service ID is used in different regions)
+func (s *Service) key() string {
+	return fmt.Sprintf("%s:%d", s.InstallationCode, s.ID)
+}
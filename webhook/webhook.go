@@ -0,0 +1,345 @@
+package webhook
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/essentialkaos/ycs"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	// HEADER_SIGNATURE is header with payload signature ("sha256=<hex>")
+	HEADER_SIGNATURE = "X-YCS-Signature"
+
+	// HEADER_TIMESTAMP is header with Unix timestamp used for signing
+	HEADER_TIMESTAMP = "X-YCS-Timestamp"
+
+	// HEADER_EVENT is header with event type
+	HEADER_EVENT = "X-YCS-Event"
+
+	// HEADER_DELIVERY is header with unique delivery ID
+	HEADER_DELIVERY = "X-YCS-Delivery"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Config contains notifier configuration
+type Config struct {
+	// URLs is a slice with webhook URLs
+	URLs []string
+
+	// Secret is secret key used for signing payloads with HMAC-SHA256
+	Secret string
+
+	// Retry is retry policy (ycs.DefaultRetryPolicy is used if MaxAttempts is 0)
+	Retry ycs.RetryPolicy
+
+	// DeadLetterFile is path to JSONL file for undeliverable payloads
+	DeadLetterFile string
+
+	// HTTPClient is HTTP client used for sending requests (client with 10
+	// seconds timeout by default)
+	HTTPClient *http.Client
+}
+
+// Notifier sends signed incident events to webhooks
+type Notifier struct {
+	cfg Config
+	mu  sync.Mutex // Dead-letter file lock
+}
+
+// Payload is webhook payload
+type Payload struct {
+	ID       string        `json:"id"`                 // Unique delivery ID
+	Type     ycs.EventType `json:"type"`               // Event type
+	Time     time.Time     `json:"time"`               // Time when change was detected
+	Incident *ycs.Incident `json:"incident,omitempty"` // Current incident state
+	Previous *ycs.Incident `json:"previous,omitempty"` // Previous incident state
+	Comments ycs.Comments  `json:"comments,omitempty"` // New comments
+	Service  *ycs.Service  `json:"service,omitempty"`  // Added or removed service
+	Diff     []ycs.Change  `json:"diff,omitempty"`     // Changed incident fields
+}
+
+// DeadLetter is record in dead-letter file
+type DeadLetter struct {
+	URL     string          `json:"url"`
+	Time    time.Time       `json:"time"`
+	Error   string          `json:"error"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewNotifier creates new webhook notifier
+func NewNotifier(cfg Config) (*Notifier, error) {
+	switch {
+	case len(cfg.URLs) == 0:
+		return nil, fmt.Errorf("At least one webhook URL must be set")
+	case cfg.Secret == "":
+		return nil, fmt.Errorf("Secret must be set")
+	}
+
+	if cfg.Retry.MaxAttempts == 0 {
+		cfg.Retry = ycs.DefaultRetryPolicy
+	}
+
+	err := cfg.Retry.Validate()
+
+	if err != nil {
+		return nil, fmt.Errorf("Invalid retry policy: %w", err)
+	}
+
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &Notifier{cfg: cfg}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Notify sends event to all webhooks. Payloads which can't be delivered are
+// saved to dead-letter file. Error events are ignored.
+func (n *Notifier) Notify(ctx context.Context, e ycs.Event) error {
+	if n == nil {
+		return fmt.Errorf("Notifier is nil")
+	}
+
+	if e.Type == ycs.EVENT_ERROR {
+		return nil
+	}
+
+	payload := NewPayload(e)
+	data, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("Can't encode payload: %w", err)
+	}
+
+	var errs []error
+
+	for _, url := range n.cfg.URLs {
+		err = n.deliver(ctx, url, payload, data)
+
+		if err == nil {
+			continue
+		}
+
+		errs = append(errs, fmt.Errorf("Can't deliver event to %s: %w", url, err))
+
+		if n.cfg.DeadLetterFile == "" {
+			continue
+		}
+
+		err = n.writeDeadLetter(url, data, err)
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewPayload creates webhook payload for given event
+func NewPayload(e ycs.Event) *Payload {
+	p := &Payload{
+		ID:       newDeliveryID(),
+		Type:     e.Type,
+		Time:     e.Time,
+		Incident: e.Incident,
+		Previous: e.Previous,
+		Comments: e.Comments,
+		Service:  e.Service,
+	}
+
+	if p.Time.IsZero() {
+		p.Time = time.Now()
+	}
+
+	if e.Incident != nil {
+		p.Diff = ycs.IncidentChanges(e.Previous, e.Incident)
+	}
+
+	return p
+}
+
+// Sign returns signature for given timestamp and payload
+func Sign(secret, timestamp string, data []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(data)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks payload signature. If maxAge is greater than 0, requests with
+// older timestamps are rejected.
+func Verify(secret, signature, timestamp string, data []byte, maxAge time.Duration) bool {
+	if signature == "" || timestamp == "" {
+		return false
+	}
+
+	if maxAge > 0 {
+		ts, err := strconv.ParseInt(timestamp, 10, 64)
+
+		if err != nil || time.Since(time.Unix(ts, 0)).Abs() > maxAge {
+			return false
+		}
+	}
+
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, data)))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// deliver sends payload to given URL with retries
+func (n *Notifier) deliver(ctx context.Context, url string, payload *Payload, data []byte) error {
+	var err error
+
+	maxAttempts := max(n.cfg.Retry.MaxAttempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		var statusCode int
+		var retry bool
+		var retryAfter, delay time.Duration
+
+		statusCode, retryAfter, retry, err = n.send(ctx, url, payload, data)
+
+		if err == nil || ctx.Err() != nil || attempt >= maxAttempts {
+			retry = false
+		}
+
+		if retry {
+			delay = n.cfg.Retry.Delay(attempt, retryAfter)
+		}
+
+		if n.cfg.Retry.OnAttempt != nil {
+			n.cfg.Retry.OnAttempt(ycs.Attempt{
+				Endpoint: url, Num: attempt, StatusCode: statusCode,
+				Err: err, Delay: delay,
+			})
+		}
+
+		if !retry {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Delivery cancelled (%w) after error: %w", ctx.Err(), err)
+		case <-time.After(delay):
+		}
+	}
+
+	return err
+}
+
+// send sends signed payload to given URL and returns response status code,
+// pause requested by receiver and flag which indicates that request can be
+// retried
+func (n *Notifier) send(ctx context.Context, url string, payload *Payload, data []byte) (int, time.Duration, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("Can't create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("User-Agent", ycs.UA)
+	req.Header.Set(HEADER_EVENT, string(payload.Type))
+	req.Header.Set(HEADER_DELIVERY, payload.ID)
+	req.Header.Set(HEADER_TIMESTAMP, timestamp)
+	req.Header.Set(HEADER_SIGNATURE, Sign(n.cfg.Secret, timestamp, data))
+
+	resp, err := n.cfg.HTTPClient.Do(req)
+
+	if err != nil {
+		return 0, 0, true, fmt.Errorf("Can't send request: %w", err)
+	}
+
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, 0, false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+
+	return resp.StatusCode, ycs.ParseRetryAfter(resp.Header.Get("Retry-After")),
+		retry, fmt.Errorf("Webhook returned non-ok status code %d", resp.StatusCode)
+}
+
+// writeDeadLetter appends undeliverable payload to dead-letter file
+func (n *Notifier) writeDeadLetter(url string, data []byte, deliveryErr error) error {
+	record, err := json.Marshal(&DeadLetter{
+		URL:     url,
+		Time:    time.Now(),
+		Error:   deliveryErr.Error(),
+		Payload: data,
+	})
+
+	if err != nil {
+		return fmt.Errorf("Can't encode dead-letter record: %w", err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	fd, err := os.OpenFile(n.cfg.DeadLetterFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+
+	if err != nil {
+		return fmt.Errorf("Can't open dead-letter file: %w", err)
+	}
+
+	_, err = fd.Write(append(record, '\n'))
+
+	if closeErr := fd.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("Can't write dead-letter file: %w", err)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newDeliveryID generates random delivery ID
+func newDeliveryID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+
+	return hex.EncodeToString(buf)
+}
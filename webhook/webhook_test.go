@@ -0,0 +1,301 @@
+package webhook
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/ycs"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const TEST_SECRET = "Test1234"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type WebhookSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&WebhookSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// receiver is a local stand-in for webhook receiver
+type receiver struct {
+	payloads   []*Payload
+	headers    []http.Header
+	failures   int    // Number of requests to fail with 503
+	retryAfter string // Fail with 429 and given Retry-After header instead of 503
+	mu         sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var fastRetry = ycs.RetryPolicy{MaxAttempts: 3, MinDelay: time.Millisecond}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *WebhookSuite) TestNotifierErrors(c *C) {
+	_, err := NewNotifier(Config{})
+	c.Assert(err, NotNil)
+
+	_, err = NewNotifier(Config{URLs: []string{"http://127.0.0.1"}})
+	c.Assert(err, NotNil)
+
+	_, err = NewNotifier(Config{
+		URLs: []string{"http://127.0.0.1"}, Secret: TEST_SECRET,
+		Retry: ycs.RetryPolicy{MaxAttempts: 1, Jitter: 2},
+	})
+	c.Assert(err, NotNil)
+
+	n, err := NewNotifier(Config{URLs: []string{"http://127.0.0.1"}, Secret: TEST_SECRET})
+	c.Assert(err, IsNil)
+	c.Assert(n.cfg.Retry.MaxAttempts, Equals, ycs.DefaultRetryPolicy.MaxAttempts)
+	c.Assert(n.cfg.HTTPClient, NotNil)
+	c.Assert(n.Notify(context.Background(), ycs.Event{Type: ycs.EVENT_ERROR}), IsNil)
+
+	n = nil
+	c.Assert(n.Notify(context.Background(), ycs.Event{}), NotNil)
+
+	n, err = NewNotifier(Config{
+		URLs: []string{"http://127.0.0.1:1"}, Secret: TEST_SECRET,
+		Retry: fastRetry, DeadLetterFile: c.MkDir(),
+	})
+	c.Assert(err, IsNil)
+	c.Assert(n.Notify(context.Background(), ycs.Event{Type: ycs.EVENT_INCIDENT_OPENED}), ErrorMatches, `(?s).*Can't open dead-letter file.*`)
+
+	n, err = NewNotifier(Config{URLs: []string{"http://127.0.0.1:1"}, Secret: TEST_SECRET, Retry: fastRetry})
+	c.Assert(err, IsNil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c.Assert(n.Notify(ctx, ycs.Event{Type: ycs.EVENT_INCIDENT_OPENED}), NotNil)
+}
+
+func (s *WebhookSuite) TestDelivery(c *C) {
+	rcv := &receiver{failures: 2}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	var attempts []ycs.Attempt
+
+	retry := fastRetry
+	retry.OnAttempt = func(a ycs.Attempt) { attempts = append(attempts, a) }
+
+	n, err := NewNotifier(Config{URLs: []string{server.URL + "/hook"}, Secret: TEST_SECRET, Retry: retry})
+	c.Assert(err, IsNil)
+
+	prev := &ycs.Incident{ID: 1, Title: "Test", Status: ycs.STATUS_OPEN}
+	cur := &ycs.Incident{ID: 1, Title: "Test", Status: ycs.STATUS_RESOLVED}
+
+	err = n.Notify(context.Background(), ycs.Event{
+		Type: ycs.EVENT_INCIDENT_RESOLVED, Incident: cur, Previous: prev,
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(attempts, HasLen, 3)
+	c.Assert(attempts[0].StatusCode, Equals, 503)
+	c.Assert(attempts[0].Delay > 0, Equals, true)
+	c.Assert(attempts[2].Err, IsNil)
+	c.Assert(attempts[2].Delay, Equals, time.Duration(0))
+
+	c.Assert(rcv.payloads, HasLen, 1)
+
+	p := rcv.payloads[0]
+
+	c.Assert(p.ID, HasLen, 32)
+	c.Assert(p.Type, Equals, ycs.EVENT_INCIDENT_RESOLVED)
+	c.Assert(p.Time.IsZero(), Equals, false)
+	c.Assert(p.Incident.ID, Equals, uint(1))
+	c.Assert(p.Previous.Status, Equals, ycs.Status(ycs.STATUS_OPEN))
+	c.Assert(p.Diff, HasLen, 1)
+	c.Assert(p.Diff[0].Field, Equals, "status")
+	c.Assert(p.Diff[0].Old, Equals, "open")
+	c.Assert(p.Diff[0].New, Equals, "resolved")
+
+	h := rcv.headers[0]
+
+	c.Assert(h.Get(HEADER_EVENT), Equals, "incident_resolved")
+	c.Assert(h.Get(HEADER_DELIVERY), Equals, p.ID)
+	c.Assert(h.Get("Content-Type"), Equals, "application/json; charset=utf-8")
+
+	err = n.Notify(context.Background(), ycs.Event{
+		Type: ycs.EVENT_SERVICE_ADDED, Service: &ycs.Service{ID: 1, Name: "Test"},
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(rcv.payloads, HasLen, 2)
+	c.Assert(rcv.payloads[1].Service.Name, Equals, "Test")
+	c.Assert(rcv.payloads[1].Diff, HasLen, 0)
+}
+
+func (s *WebhookSuite) TestRetryAfter(c *C) {
+	rcv := &receiver{failures: 1, retryAfter: "1"}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	var attempts []ycs.Attempt
+
+	retry := fastRetry
+	retry.MaxDelay = 20 * time.Millisecond
+	retry.OnAttempt = func(a ycs.Attempt) { attempts = append(attempts, a) }
+
+	n, err := NewNotifier(Config{URLs: []string{server.URL + "/hook"}, Secret: TEST_SECRET, Retry: retry})
+	c.Assert(err, IsNil)
+
+	event := ycs.Event{Type: ycs.EVENT_INCIDENT_OPENED, Incident: &ycs.Incident{ID: 1}}
+
+	c.Assert(n.Notify(context.Background(), event), IsNil)
+	c.Assert(attempts, HasLen, 2)
+	c.Assert(attempts[0].StatusCode, Equals, 429)
+	c.Assert(attempts[0].Delay, Equals, 20*time.Millisecond)
+
+	rcv.mu.Lock()
+	rcv.failures = 100
+	rcv.mu.Unlock()
+
+	retry.MaxDelay = 0
+
+	n, err = NewNotifier(Config{URLs: []string{server.URL + "/hook"}, Secret: TEST_SECRET, Retry: retry})
+	c.Assert(err, IsNil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = n.Notify(ctx, event)
+
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+	c.Assert(err, ErrorMatches, `Can't deliver event to .+/hook: Delivery cancelled \(context deadline exceeded\) after error: Webhook returned non-ok status code 429`)
+}
+
+func (s *WebhookSuite) TestDeadLetter(c *C) {
+	rcv := &receiver{failures: 100}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	dlFile := c.MkDir() + "/dead.jsonl"
+	attempts := 0
+
+	retry := fastRetry
+	retry.OnAttempt = func(a ycs.Attempt) { attempts++ }
+
+	n, err := NewNotifier(Config{
+		URLs:           []string{server.URL + "/hook", server.URL + "/bad-request"},
+		Secret:         TEST_SECRET,
+		Retry:          retry,
+		DeadLetterFile: dlFile,
+	})
+	c.Assert(err, IsNil)
+
+	err = n.Notify(context.Background(), ycs.Event{
+		Type: ycs.EVENT_INCIDENT_OPENED, Incident: &ycs.Incident{ID: 1, Title: "Test"},
+	})
+
+	c.Assert(err, ErrorMatches, `(?s)Can't deliver event to .+/hook: Webhook returned non-ok status code 503\nCan't deliver event to .+/bad-request: Webhook returned non-ok status code 400`)
+	c.Assert(attempts, Equals, 4) // 3 attempts for 503 and 1 for 400
+
+	fd, err := os.Open(dlFile)
+	c.Assert(err, IsNil)
+	defer fd.Close()
+
+	var records []*DeadLetter
+
+	scanner := bufio.NewScanner(fd)
+
+	for scanner.Scan() {
+		r := &DeadLetter{}
+		c.Assert(json.Unmarshal(scanner.Bytes(), r), IsNil)
+		records = append(records, r)
+	}
+
+	c.Assert(records, HasLen, 2)
+	c.Assert(records[0].URL, Equals, server.URL+"/hook")
+	c.Assert(records[0].Error, Equals, "Webhook returned non-ok status code 503")
+	c.Assert(records[1].URL, Equals, server.URL+"/bad-request")
+
+	p := &Payload{}
+	c.Assert(json.Unmarshal(records[0].Payload, p), IsNil)
+	c.Assert(p.Incident.Title, Equals, "Test")
+}
+
+func (s *WebhookSuite) TestSignature(c *C) {
+	data := []byte(`{"id":"1"}`)
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	sig := Sign(TEST_SECRET, ts, data)
+
+	c.Assert(sig, Matches, `sha256=[0-9a-f]{64}`)
+	c.Assert(Sign(TEST_SECRET, "1700000000", data), Equals, "sha256=5b710690f19d1c729d0332870b192a8d599ffd879c1ba36ec113f94ecef2ce8e")
+
+	c.Assert(Verify(TEST_SECRET, sig, ts, data, time.Minute), Equals, true)
+	c.Assert(Verify(TEST_SECRET, sig, ts, data, 0), Equals, true)
+	c.Assert(Verify("ABCD", sig, ts, data, time.Minute), Equals, false)
+	c.Assert(Verify(TEST_SECRET, sig, ts, []byte(`{"id":"2"}`), time.Minute), Equals, false)
+	c.Assert(Verify(TEST_SECRET, "", ts, data, time.Minute), Equals, false)
+	c.Assert(Verify(TEST_SECRET, sig, "ABCD", data, time.Minute), Equals, false)
+
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	c.Assert(Verify(TEST_SECRET, Sign(TEST_SECRET, old, data), old, data, time.Minute), Equals, false)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (r *receiver) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, _ := io.ReadAll(req.Body)
+
+	if !Verify(TEST_SECRET, req.Header.Get(HEADER_SIGNATURE), req.Header.Get(HEADER_TIMESTAMP), data, time.Minute) {
+		rw.WriteHeader(401)
+		return
+	}
+
+	if req.URL.Path == "/bad-request" {
+		rw.WriteHeader(400)
+		return
+	}
+
+	if r.failures > 0 {
+		r.failures--
+
+		if r.retryAfter != "" {
+			rw.Header().Set("Retry-After", r.retryAfter)
+			rw.WriteHeader(429)
+		} else {
+			rw.WriteHeader(503)
+		}
+
+		return
+	}
+
+	p := &Payload{}
+	json.Unmarshal(data, p)
+
+	r.payloads = append(r.payloads, p)
+	r.headers = append(r.headers, req.Header)
+
+	rw.WriteHeader(204)
+}
@@ -8,14 +8,16 @@ package ycs
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"html"
-	"regexp"
+	"io"
+	"iter"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/essentialkaos/ek/v13/req"
-	"github.com/essentialkaos/ek/v13/reutil"
 	"github.com/essentialkaos/ek/v13/sliceutil"
 	"github.com/essentialkaos/ek/v13/strutil"
 	"github.com/essentialkaos/ek/v13/timeutil"
@@ -26,6 +28,9 @@ import (
 // UA is HTTP client user-agent
 const UA = "EK|YCS.go"
 
+// API_URL is default Yandex.Cloud status API URL
+const API_URL = "https://status.yandex.cloud/api"
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 const (
@@ -47,6 +52,11 @@ const (
 	ZONE_KZ_A = "kz1-a"
 )
 
+const (
+	TAG_NEW     = "new"
+	TAG_PREVIEW = "preview"
+)
+
 const (
 	STATUS_OPEN        = "open"
 	STATUS_RESOLVED    = "resolved"
@@ -65,12 +75,29 @@ const (
 )
 
 const (
-	LEVEL_ID_MINOR       uint8 = 1
-	LEVEL_ID_UNAVAILABLE uint8 = 2
+	LEVEL_ID_MINOR       = 1
+	LEVEL_ID_UNAVAILABLE = 2
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// Client is Yandex.Cloud status API client
+type Client struct {
+	engine  *req.Engine
+	limiter *limiter
+	retry   RetryPolicy
+	url     string
+}
+
+// APIError is error returned if API responded with non-ok status code
+type APIError struct {
+	StatusCode int    // HTTP status code
+	Endpoint   string // API endpoint
+	Body       string // Response body (up to 4 KB)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // Date is JSON date
 type Date struct {
 	time.Time
@@ -80,26 +107,30 @@ type Date struct {
 
 // Service contains info about service
 type Service struct {
-	Name             string    `json:"name"`
-	FullName         string    `json:"fullName"`
-	Slug             string    `json:"slug"`
-	Description      string    `json:"description"`
-	IAMFlag          string    `json:"iamFlag,omitempty"`
-	Status           string    `json:"status"`
-	DocURL           string    `json:"docUrl"`
-	PricesURL        string    `json:"pricesUrl"`
-	ConsoleURL       string    `json:"consoleUrl"`
-	InstallationCode string    `json:"installationCode"`
-	Icon             string    `json:"icon"`
-	IconName         string    `json:"iconName"`
-	OrderNumber      uint      `json:"orderNumber"`
-	CategoryID       uint      `json:"categoryId"`
-	ID               uint      `json:"id"`
-	PageID           uint      `json:"pageId"`
-	CreatedAt        Date      `json:"createdAt"`
-	UpdatedAt        Date      `json:"updatedAt"`
-	IsProduct        bool      `json:"isProduct"`
-	Incidents        Incidents `json:"incidents"`
+	Name             string     `json:"name"`
+	FullName         string     `json:"fullName"`
+	Slug             string     `json:"slug"`
+	Description      string     `json:"description"`
+	IAMFlag          string     `json:"iamFlag,omitempty"`
+	Status           string     `json:"status"`
+	DocURL           string     `json:"docUrl"`
+	PricesURL        string     `json:"pricesUrl"`
+	ConsoleURL       string     `json:"consoleUrl"`
+	InstallationCode RegionCode `json:"installationCode"`
+	Icon             string     `json:"icon"`
+	IconName         string     `json:"iconName"`
+	Tag              string     `json:"tag"`
+	OrderNumber      uint       `json:"orderNumber"`
+	CategoryID       uint       `json:"categoryId"`
+	ID               uint       `json:"id"`
+	PageID           uint       `json:"pageId"`
+	ServicePageID    uint       `json:"servicePageId"`
+	CreatedAt        Date       `json:"createdAt"`
+	UpdatedAt        Date       `json:"updatedAt"`
+	IsProduct        bool       `json:"isProduct"`
+	HasEnFallback    bool       `json:"hasEnFallback"`
+	Zones            Zones      `json:"zones"`
+	Incidents        Incidents  `json:"incidents"`
 }
 
 // Services is slice with services
@@ -110,9 +141,9 @@ type Incident struct {
 	ID                  uint     `json:"id"`
 	Title               string   `json:"title"`
 	Report              string   `json:"report,omitempty"`
-	Status              string   `json:"status"`
+	Status              Status   `json:"status"`
 	IsReportPublished   bool     `json:"isReportPublished,omitempty"`
-	LevelID             uint8    `json:"levelId"`
+	LevelID             LevelID  `json:"levelId"`
 	StartDate           Date     `json:"startDate"`
 	EndDate             Date     `json:"endDate"`
 	CreatedAt           Date     `json:"createdAt"`
@@ -130,11 +161,11 @@ type Incidents []*Incident
 
 // Level contains info about incident level
 type Level struct {
-	Level     uint8  `json:"level"`
-	Label     string `json:"label"`
-	Theme     string `json:"theme"`
-	CreatedAt Date   `json:"createdAt"`
-	UpdatedAt Date   `json:"updatedAt"`
+	Level     LevelID `json:"level"`
+	Label     string  `json:"label"`
+	Theme     string  `json:"theme"`
+	CreatedAt Date    `json:"createdAt"`
+	UpdatedAt Date    `json:"updatedAt"`
 }
 
 // Zone contains info about zone (RU/KZ)
@@ -151,20 +182,21 @@ type Zones []*Zone
 
 // Region contains info about installation region
 type Region struct {
-	Code  string `json:"code"`
-	Zones Zones  `json:"zones"`
+	Code  RegionCode `json:"code"`
+	Zones Zones      `json:"zones"`
 }
 
 // Regions is a slice with installation regions
 type Regions []*Region
 
+// Comment contains info about incident comment
 type Comment struct {
-	ID         uint   `json:"id"`
-	IncidentID uint   `json:"incidentId"`
-	Content    string `json:"content"`
-	Type       string `json:"type"`
-	CreatedAt  Date   `json:"createdAt"`
-	UpdatedAt  Date   `json:"updatedAt"`
+	ID         uint        `json:"id"`
+	IncidentID uint        `json:"incidentId"`
+	Content    string      `json:"content"`
+	Type       CommentType `json:"type"`
+	CreatedAt  Date        `json:"createdAt"`
+	UpdatedAt  Date        `json:"updatedAt"`
 }
 
 // Comments is a slice with comments
@@ -174,12 +206,21 @@ type Comments []*Comment
 
 // IncidentsRequest contains incident request info
 type IncidentsRequest struct {
-	Lang   string
+	Lang   Lang
 	From   time.Time
 	To     time.Time
-	Status string
-	Region string
+	Status Status
+	Region RegionCode
 	Zones  []string
+
+	Page     int // Page number (starting from 1)
+	PageSize int // Number of incidents per page
+}
+
+// IncidentsPage contains page with incidents
+type IncidentsPage struct {
+	Items Incidents `json:"items"`
+	Count int       `json:"count"` // Total number of incidents matching request
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -195,52 +236,181 @@ var AllZones = []string{ZONE_KZ_A, ZONE_RU_A, ZONE_RU_B, ZONE_RU_C, ZONE_RU_D}
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// engine is HTTP client
-var engine *req.Engine
+var (
+	// ErrNilClient is returned if client struct is nil
+	ErrNilClient = fmt.Errorf("Client is nil")
 
-// apiURL is Yandex.Cloud status API URL
-var apiURL = "https://status.yandex.cloud/api"
+	// ErrNotFound is returned if requested object doesn't exist (HTTP 404)
+	ErrNotFound = fmt.Errorf("Not found")
 
-var (
-	htmlTagStartRegex = regexp.MustCompile(`<(strong|pre|code|ol|ul|li|br|i|b|p)[^>]*\/?>($|\n)?`)
-	htmlTagEndRegex   = regexp.MustCompile(`<\/(strong|pre|code|ol|ul|li|i|b|p)\/?>`)
-	htmlImgTagRegex   = regexp.MustCompile(`<img src=\"([^"]+)\"[^>]+\>`)
-	htmlLinkTagRegex  = regexp.MustCompile(`<a href=\"([^"]+)\"[^>]*>([^<]+)<\/a>`)
+	// ErrRateLimited is returned if API rejected request due to rate limits (HTTP 429)
+	ErrRateLimited = fmt.Errorf("Rate limit exceeded")
+
+	// ErrServerError is returned if API failed to process request (HTTP 5xx)
+	ErrServerError = fmt.Errorf("Server error")
+
+	// ErrDecode is returned if API response can't be decoded
+	ErrDecode = fmt.Errorf("Can't decode API response")
 )
 
+// maxErrorBodySize is maximum size of response body stored in APIError
+const maxErrorBodySize = 4096
+
+// defaultClient is client used by package-level functions
+var defaultClient = NewClient()
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// SetUserAgent sets user agent
+// SetUserAgent sets user agent for default client
 func SetUserAgent(app, version string) {
-	initEngine()
-	engine.SetUserAgent(app, version, UA+"/1")
+	defaultClient.SetUserAgent(app, version)
 }
 
-// SetLimit sets a hard limit on the number of requests per second
+// SetLimit sets a hard limit on the number of requests per second for default client
 func SetLimit(rps float64) {
-	initEngine()
-	engine.SetLimit(rps)
+	defaultClient.SetLimit(rps)
 }
 
-// SetRequestTimeout sets request timeout
+// SetRequestTimeout sets request timeout for default client
 func SetRequestTimeout(timeout float64) {
-	initEngine()
-	engine.SetRequestTimeout(timeout)
+	defaultClient.SetRequestTimeout(timeout)
+}
+
+// GetServices returns status of all services using default client
+func GetServices(lang Lang) (Services, error) {
+	return defaultClient.GetServices(lang)
+}
+
+// GetServicesContext returns status of all services using default client
+// and given context
+func GetServicesContext(ctx context.Context, lang Lang) (Services, error) {
+	return defaultClient.GetServicesContext(ctx, lang)
+}
+
+// GetIncidents returns slice with incidents using default client
+func GetIncidents(req IncidentsRequest) (Incidents, error) {
+	return defaultClient.GetIncidents(req)
+}
+
+// GetIncidentsContext returns slice with incidents using default client
+// and given context
+func GetIncidentsContext(ctx context.Context, req IncidentsRequest) (Incidents, error) {
+	return defaultClient.GetIncidentsContext(ctx, req)
+}
+
+// GetIncidentsPage returns page with incidents using default client
+func GetIncidentsPage(req IncidentsRequest) (*IncidentsPage, error) {
+	return defaultClient.GetIncidentsPage(req)
+}
+
+// GetIncidentsPageContext returns page with incidents using default client
+// and given context
+func GetIncidentsPageContext(ctx context.Context, req IncidentsRequest) (*IncidentsPage, error) {
+	return defaultClient.GetIncidentsPageContext(ctx, req)
+}
+
+// IterIncidents returns iterator over all incidents on all pages using default client
+func IterIncidents(req IncidentsRequest) iter.Seq2[*Incident, error] {
+	return defaultClient.IterIncidents(req)
+}
+
+// IterIncidentsContext returns iterator over all incidents on all pages using
+// default client and given context
+func IterIncidentsContext(ctx context.Context, req IncidentsRequest) iter.Seq2[*Incident, error] {
+	return defaultClient.IterIncidentsContext(ctx, req)
+}
+
+// GetIncident returns info about incident with given ID using default client
+func GetIncident(id uint, lang Lang) (*Incident, error) {
+	return defaultClient.GetIncident(id, lang)
+}
+
+// GetIncidentContext returns info about incident with given ID using default
+// client and given context
+func GetIncidentContext(ctx context.Context, id uint, lang Lang) (*Incident, error) {
+	return defaultClient.GetIncidentContext(ctx, id, lang)
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// NewClient creates new API client. Client uses DefaultRetryPolicy, retries can
+// be disabled using SetRetryPolicy with empty policy.
+func NewClient() *Client {
+	c := &Client{
+		engine: &req.Engine{},
+		url:    API_URL,
+		retry:  DefaultRetryPolicy,
+	}
+
+	c.engine.SetUserAgent(UA, "1")
+
+	return c
+}
+
+// SetURL sets API URL
+func (c *Client) SetURL(url string) {
+	if c == nil || url == "" {
+		return
+	}
+
+	c.url = strings.TrimRight(url, "/")
+}
+
+// SetUserAgent sets user agent
+func (c *Client) SetUserAgent(app, version string) {
+	if c == nil {
+		return
+	}
+
+	c.engine.SetUserAgent(app, version, UA+"/1")
+}
+
+// SetLimit sets a hard limit on the number of requests per second
+func (c *Client) SetLimit(rps float64) {
+	if c == nil {
+		return
+	}
+
+	c.limiter = newLimiter(rps)
+}
+
+// SetRequestTimeout sets request timeout
+func (c *Client) SetRequestTimeout(timeout float64) {
+	if c == nil {
+		return
+	}
+
+	c.engine.SetRequestTimeout(timeout)
+}
+
+// SetTransport sets HTTP transport used for sending requests
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	if c == nil || transport == nil {
+		return
+	}
+
+	c.engine.Init()
+	c.engine.Client.Transport = transport
+}
+
 // GetServices returns status of all services
-func GetServices(lang string) (Services, error) {
-	initEngine()
+func (c *Client) GetServices(lang Lang) (Services, error) {
+	return c.GetServicesContext(context.Background(), lang)
+}
+
+// GetServicesContext returns status of all services using given context
+func (c *Client) GetServicesContext(ctx context.Context, lang Lang) (Services, error) {
+	if c == nil {
+		return nil, ErrNilClient
+	}
 
 	resp := Services{}
 
-	err := sendRequest(
-		"/services",
+	err := c.sendRequest(
+		ctx, "/services",
 		req.Query{
 			"incidents": "all",
-			"lang":      strutil.Q(lang, LANG_RU),
+			"lang":      lang.orDefault(),
 		},
 		&resp,
 	)
@@ -253,15 +423,42 @@ func GetServices(lang string) (Services, error) {
 }
 
 // GetIncidents returns slice with incidents
-func GetIncidents(req IncidentsRequest) (Incidents, error) {
-	initEngine()
+func (c *Client) GetIncidents(req IncidentsRequest) (Incidents, error) {
+	return c.GetIncidentsContext(context.Background(), req)
+}
+
+// GetIncidentsContext returns slice with incidents using given context
+func (c *Client) GetIncidentsContext(ctx context.Context, req IncidentsRequest) (Incidents, error) {
+	page, err := c.GetIncidentsPageContext(ctx, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Items, nil
+}
+
+// GetIncidentsPage returns page with incidents
+func (c *Client) GetIncidentsPage(req IncidentsRequest) (*IncidentsPage, error) {
+	return c.GetIncidentsPageContext(context.Background(), req)
+}
+
+// GetIncidentsPageContext returns page with incidents using given context
+func (c *Client) GetIncidentsPageContext(ctx context.Context, req IncidentsRequest) (*IncidentsPage, error) {
+	if c == nil {
+		return nil, ErrNilClient
+	}
+
+	err := req.Validate()
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't get incidents: %w", err)
+	}
 
-	resp := &struct {
-		Items Incidents `json:"items"`
-	}{}
+	resp := &IncidentsPage{}
 
-	err := sendRequest(
-		"/incidents",
+	err = c.sendRequest(
+		ctx, "/incidents",
 		convertIncidentsRequest(req),
 		&resp,
 	)
@@ -270,18 +467,72 @@ func GetIncidents(req IncidentsRequest) (Incidents, error) {
 		return nil, fmt.Errorf("Can't get incidents: %w", err)
 	}
 
-	return resp.Items, nil
+	return resp, nil
 }
 
-// GetIncidents returns slice with incidents
-func GetIncident(id uint, lang string) (*Incident, error) {
-	initEngine()
+// IterIncidents returns iterator over all incidents on all pages
+func (c *Client) IterIncidents(req IncidentsRequest) iter.Seq2[*Incident, error] {
+	return c.IterIncidentsContext(context.Background(), req)
+}
+
+// IterIncidentsContext returns iterator over all incidents on all pages using
+// given context
+func (c *Client) IterIncidentsContext(ctx context.Context, req IncidentsRequest) iter.Seq2[*Incident, error] {
+	return func(yield func(*Incident, error) bool) {
+		var fetched int
+
+		seen := map[uint]bool{}
+		req.Page = max(req.Page, 1)
+
+		for {
+			page, err := c.GetIncidentsPageContext(ctx, req)
+
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			var hasNew bool
+
+			for _, i := range page.Items {
+				if seen[i.ID] {
+					continue
+				}
+
+				seen[i.ID], hasNew = true, true
+				fetched++
+
+				if !yield(i, nil) {
+					return
+				}
+			}
+
+			// Stop if we got all incidents or API returned the same page again
+			if !hasNew || fetched >= page.Count {
+				return
+			}
+
+			req.Page++
+		}
+	}
+}
+
+// GetIncident returns info about incident with given ID
+func (c *Client) GetIncident(id uint, lang Lang) (*Incident, error) {
+	return c.GetIncidentContext(context.Background(), id, lang)
+}
+
+// GetIncidentContext returns info about incident with given ID using given context
+func (c *Client) GetIncidentContext(ctx context.Context, id uint, lang Lang) (*Incident, error) {
+	if c == nil {
+		return nil, ErrNilClient
+	}
 
 	resp := &Incident{}
 
-	err := sendRequest(
-		fmt.Sprintf("/incidents/%d", id),
-		req.Query{"lang": strutil.Q(lang, LANG_RU)},
+	err := c.sendRequest(
+		ctx, fmt.Sprintf("/incidents/%d", id),
+		req.Query{"lang": lang.orDefault()},
 		&resp,
 	)
 
@@ -320,7 +571,7 @@ func (i *Incident) Duration() time.Duration {
 }
 
 // URL returns URL of incident page
-func (i *Incident) URL(lang string) string {
+func (i *Incident) URL(lang Lang) string {
 	if i == nil || i.ID == 0 {
 		return ""
 	}
@@ -340,6 +591,26 @@ func (i *Incident) ReportMarkdown() string {
 	return htmlToMarkdown(i.Report)
 }
 
+// ReportText converts report HTML to plain text wrapped to given width
+// (0 = no wrapping). Links are replaced by footnotes.
+func (i *Incident) ReportText(width int) string {
+	if i == nil {
+		return ""
+	}
+
+	return htmlToText(i.Report, width, false)
+}
+
+// ReportANSI converts report HTML to text with ANSI styles wrapped to given
+// width (0 = no wrapping). Links are replaced by footnotes.
+func (i *Incident) ReportANSI(width int) string {
+	if i == nil {
+		return ""
+	}
+
+	return htmlToText(i.Report, width, true)
+}
+
 // RegionList returns slice with all regions affected by the incident
 func (i *Incident) RegionList() []string {
 	if i == nil || len(i.Regions) == 0 {
@@ -349,7 +620,7 @@ func (i *Incident) RegionList() []string {
 	var result []string
 
 	for _, r := range i.Regions {
-		result = append(result, r.Code)
+		result = append(result, string(r.Code))
 	}
 
 	return result
@@ -388,12 +659,19 @@ func (i *Incident) ServiceList() []string {
 }
 
 // InRegion filters services and returns only services in a given region (installation)
-func (s Services) InRegion(code string) Services {
+func (s Services) InRegion(code RegionCode) Services {
 	return sliceutil.Filter(s, func(ss *Service, _ int) bool {
 		return ss.InstallationCode == code
 	})
 }
 
+// InZone filters services and returns only services available in a given zone
+func (s Services) InZone(zone string) Services {
+	return sliceutil.Filter(s, func(ss *Service, _ int) bool {
+		return ss.HasZone(zone)
+	})
+}
+
 // IDs returns slice with IDs of services
 func (s Services) IDs() []uint {
 	var result []uint
@@ -416,6 +694,41 @@ func (s Services) Names() []string {
 	return result
 }
 
+// ZoneList returns slice with all zones where service is available
+func (s *Service) ZoneList() []string {
+	if s == nil || len(s.Zones) == 0 {
+		return nil
+	}
+
+	var result []string
+
+	for _, z := range s.Zones {
+		result = append(result, z.ID)
+	}
+
+	return result
+}
+
+// HasZone returns true if service is available in given zone
+func (s *Service) HasZone(zone string) bool {
+	if s == nil {
+		return false
+	}
+
+	for _, z := range s.Zones {
+		if z.ID == zone {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsPreview returns true if service is in preview stage
+func (s *Service) IsPreview() bool {
+	return s != nil && s.Tag == TAG_PREVIEW
+}
+
 // Get returns comment with given index
 func (c Comments) Get(index int) *Comment {
 	if len(c) == 0 || index >= len(c) {
@@ -425,6 +738,32 @@ func (c Comments) Get(index int) *Comment {
 	return c[index]
 }
 
+// Latest returns the most recent comment
+func (c Comments) Latest() *Comment {
+	var result *Comment
+
+	for _, cc := range c {
+		if cc != nil && (result == nil || cc.CreatedAt.After(result.CreatedAt.Time)) {
+			result = cc
+		}
+	}
+
+	return result
+}
+
+// IDs returns slice with comments IDs
+func (c Comments) IDs() []uint {
+	var result []uint
+
+	for _, cc := range c {
+		if cc != nil {
+			result = append(result, cc.ID)
+		}
+	}
+
+	return result
+}
+
 // Markdown converts comment HTML content to Markdown
 func (c *Comment) Markdown() string {
 	if c == nil || c.Content == "" {
@@ -434,51 +773,113 @@ func (c *Comment) Markdown() string {
 	return htmlToMarkdown(c.Content)
 }
 
-// ////////////////////////////////////////////////////////////////////////////////// //
+// Text converts comment HTML content to plain text wrapped to given width
+// (0 = no wrapping)
+func (c *Comment) Text(width int) string {
+	if c == nil || c.Content == "" {
+		return ""
+	}
 
-// initEngine inits request engine
-func initEngine() {
-	if engine != nil {
-		return
+	return htmlToText(c.Content, width, false)
+}
+
+// ANSI converts comment HTML content to text with ANSI styles wrapped to
+// given width (0 = no wrapping)
+func (c *Comment) ANSI(width int) string {
+	if c == nil || c.Content == "" {
+		return ""
 	}
 
-	engine = &req.Engine{}
-	engine.SetUserAgent(UA, "1")
+	return htmlToText(c.Content, width, true)
 }
 
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // sendRequest sends request to API
-func sendRequest(endpoint string, query req.Query, response any) error {
-	resp, err := engine.Get(req.Request{
-		URL:         apiURL + endpoint,
-		Query:       query,
-		Accept:      req.CONTENT_TYPE_JSON,
-		AutoDiscard: true,
-	})
+func (c *Client) sendRequest(ctx context.Context, endpoint string, query req.Query, response any) error {
+	c.engine.Init()
+
+	url := c.url + endpoint
+
+	if len(query) != 0 {
+		url += "?" + query.Encode()
+	}
+
+	for attempt := 1; ; attempt++ {
+		retryAfter, retry, err := c.doRequest(ctx, url, endpoint, response)
+
+		if err == nil || !retry || attempt >= c.retry.attempts() {
+			c.retry.notify(Attempt{Endpoint: endpoint, Num: attempt, Err: err})
+			return err
+		}
+
+		delay := c.retry.Delay(attempt, retryAfter)
+
+		c.retry.notify(Attempt{Endpoint: endpoint, Num: attempt, Err: err, Delay: delay})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Can't send request to API: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// doRequest sends single request to API and returns pause requested by API,
+// flag of possibility to retry the request and error
+func (c *Client) doRequest(ctx context.Context, url, endpoint string, response any) (time.Duration, bool, error) {
+	r, err := http.NewRequestWithContext(ctx, req.GET, url, nil)
+
+	if err != nil {
+		return 0, false, fmt.Errorf("Can't create request: %w", err)
+	}
+
+	r.Header.Set("Accept", req.CONTENT_TYPE_JSON)
+	r.Header.Set("User-Agent", c.engine.UserAgent)
+
+	err = c.limiter.Wait(ctx)
+
+	if err != nil {
+		return 0, false, fmt.Errorf("Can't send request to API: %w", err)
+	}
+
+	resp, err := c.engine.Client.Do(r)
 
 	if err != nil {
-		return fmt.Errorf("Can't send request to API: %w", err)
+		return 0, ctx.Err() == nil, fmt.Errorf("Can't send request to API: %w", err)
 	}
 
+	defer resp.Body.Close()
+
 	if resp.StatusCode > 299 {
-		return fmt.Errorf("API returned non-ok status code %d", resp.StatusCode)
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		io.Copy(io.Discard, resp.Body)
+
+		return ParseRetryAfter(resp.Header.Get("Retry-After")),
+			isRetryableStatus(resp.StatusCode),
+			&APIError{
+				StatusCode: resp.StatusCode,
+				Endpoint:   endpoint,
+				Body:       string(body),
+			}
 	}
 
 	if response != nil {
-		err = resp.JSON(response)
+		err = json.NewDecoder(resp.Body).Decode(response)
 
 		if err != nil {
-			return fmt.Errorf("Can't decode API response: %w", err)
+			return 0, false, fmt.Errorf("%w: %w", ErrDecode, err)
 		}
 	}
 
-	return nil
+	return 0, false, nil
 }
 
 // convertIncidentsRequest converts incidents request to query
 func convertIncidentsRequest(r IncidentsRequest) req.Query {
 	q := req.Query{
-		"lang":         strutil.Q(r.Lang, LANG_RU),
-		"installation": strutil.Q(r.Region, "all"),
+		"lang":         r.Lang.orDefault(),
+		"installation": strutil.Q(string(r.Region), REGION_ALL),
 	}
 
 	if !r.From.IsZero() {
@@ -490,18 +891,47 @@ func convertIncidentsRequest(r IncidentsRequest) req.Query {
 	}
 
 	if r.Status != "" {
-		q["status"] = r.Status
+		q["status"] = string(r.Status)
 	}
 
 	if len(r.Zones) > 0 {
 		q["zones[]"] = r.Zones
 	}
 
+	if r.Page > 0 {
+		q["page"] = r.Page
+	}
+
+	if r.PageSize > 0 {
+		q["limit"] = r.PageSize
+	}
+
 	return q
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// Error returns error message
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API returned non-ok status code %d", e.StatusCode)
+}
+
+// Is returns true if error matches given sentinel error
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= 500
+	}
+
+	return false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // UnmarshalJSON parses JSON date
 func (d *Date) UnmarshalJSON(b []byte) error {
 	data := string(b)
@@ -522,68 +952,11 @@ func (d *Date) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-// ////////////////////////////////////////////////////////////////////////////////// //
-
-// htmlToMarkdown is simple html to markdown converter
-func htmlToMarkdown(text string) string {
-	if strings.Contains(text, "<img") {
-		text, _ = reutil.Replace(htmlImgTagRegex, text, func(found string, submatch []string) string {
-			return fmt.Sprintf("![IMG](%s)", submatch[0])
-		})
-	}
-
-	if strings.Contains(text, "<a ") {
-		text, _ = reutil.Replace(htmlLinkTagRegex, text, func(found string, submatch []string) string {
-			return fmt.Sprintf("[%s](%s)", submatch[1], submatch[0])
-		})
-	}
-
-	index := 0
-
-	text, _ = reutil.Replace(htmlTagStartRegex, text, func(found string, submatch []string) string {
-		switch submatch[0] {
-		case "i":
-			return "*"
-		case "b", "strong":
-			return "**"
-		case "pre":
-			return "`"
-		case "code":
-			return "```"
-		case "ol":
-			index = 1
-			return ""
-		case "ul":
-			index = 0
-			return ""
-		case "br":
-			return "\n"
-		case "li":
-			if index == 0 {
-				return "• "
-			} else {
-				index += 1
-				return fmt.Sprintf("%d. ", index-1)
-			}
-		}
-
-		return ""
-	})
-
-	text, _ = reutil.Replace(htmlTagEndRegex, text, func(found string, submatch []string) string {
-		switch submatch[0] {
-		case "i":
-			return "*"
-		case "b", "strong":
-			return "**"
-		case "pre":
-			return "`"
-		case "code":
-			return "```"
-		}
-
-		return ""
-	})
+// MarshalJSON encodes date to JSON using the same format as API
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
 
-	return html.UnescapeString(text)
+	return []byte(d.UTC().Format(`"2006-01-02T15:04:05.000Z"`)), nil
 }
@@ -8,11 +8,27 @@ package ycs
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	. "github.com/essentialkaos/check"
 )
@@ -23,10 +39,19 @@ const TEST_PORT = "56123"
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+var flakyCounter atomic.Int32
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 func Test(t *testing.T) { TestingT(t) }
 
 type YCSSuite struct{}
 
+// basicStateStore is state store without batch support
+type basicStateStore struct {
+	store *MemoryStateStore
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 var _ = Suite(&YCSSuite{})
@@ -34,7 +59,8 @@ var _ = Suite(&YCSSuite{})
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 func (s *YCSSuite) SetUpSuite(c *C) {
-	apiURL = "http://127.0.0.1:" + TEST_PORT
+	defaultClient.SetURL("http://127.0.0.1:" + TEST_PORT)
+	defaultClient.SetRetryPolicy(RetryPolicy{})
 
 	mux := http.NewServeMux()
 	server := &http.Server{Addr: ":" + TEST_PORT, Handler: mux}
@@ -42,6 +68,8 @@ func (s *YCSSuite) SetUpSuite(c *C) {
 	mux.HandleFunc("GET /services", handlerServices)
 	mux.HandleFunc("GET /incidents", handlerIncidents)
 	mux.HandleFunc("GET /incidents/972", handlerIncident)
+	mux.HandleFunc("GET /incidents/1", handlerSlow)
+	mux.HandleFunc("GET /incidents/3", handlerFlaky)
 
 	go server.ListenAndServe()
 
@@ -55,9 +83,52 @@ func (s *YCSSuite) TestInit(c *C) {
 	SetLimit(100)
 	SetRequestTimeout(0.1)
 
-	c.Assert(engine, NotNil)
+	c.Assert(defaultClient, NotNil)
+	c.Assert(defaultClient.engine.UserAgent, Matches, "Test/1.2.3 .*")
 
-	engine = nil
+	defaultClient = NewClient()
+	defaultClient.SetURL("http://127.0.0.1:" + TEST_PORT)
+	defaultClient.SetRetryPolicy(RetryPolicy{})
+}
+
+func (s *YCSSuite) TestClient(c *C) {
+	client := NewClient()
+
+	client.SetURL("http://127.0.0.1:" + TEST_PORT + "/")
+	client.SetUserAgent("Client", "2.0.0")
+	client.SetLimit(100)
+	client.SetRequestTimeout(5)
+	client.SetTransport(&http.Transport{})
+
+	c.Assert(client.url, Equals, "http://127.0.0.1:"+TEST_PORT)
+	c.Assert(client.engine.UserAgent, Not(Equals), defaultClient.engine.UserAgent)
+
+	services, err := client.GetServices(LANG_EN)
+	c.Assert(err, IsNil)
+	c.Assert(services, HasLen, 104)
+
+	incidents, err := client.GetIncidents(IncidentsRequest{})
+	c.Assert(err, IsNil)
+	c.Assert(incidents, Not(HasLen), 0)
+
+	incident, err := client.GetIncident(972, LANG_EN)
+	c.Assert(err, IsNil)
+	c.Assert(incident, NotNil)
+
+	client = nil
+
+	client.SetURL("http://127.0.0.1")
+	client.SetUserAgent("Client", "2.0.0")
+	client.SetLimit(100)
+	client.SetRequestTimeout(5)
+	client.SetTransport(&http.Transport{})
+
+	_, err = client.GetServices(LANG_EN)
+	c.Assert(err, Equals, ErrNilClient)
+	_, err = client.GetIncidents(IncidentsRequest{})
+	c.Assert(err, Equals, ErrNilClient)
+	_, err = client.GetIncident(972, LANG_EN)
+	c.Assert(err, Equals, ErrNilClient)
 }
 
 func (s *YCSSuite) TestGetServices(c *C) {
@@ -69,6 +140,30 @@ func (s *YCSSuite) TestGetServices(c *C) {
 	c.Assert(services.InRegion(REGION_RU), HasLen, 74)
 	c.Assert(services.IDs(), HasLen, 104)
 	c.Assert(services.Names(), HasLen, 104)
+	c.Assert(services.InZone(ZONE_RU_A), HasLen, 38)
+	c.Assert(services.InZone(ZONE_RU_D), HasLen, 0)
+
+	var service *Service
+
+	for _, ss := range services {
+		if ss.Slug == "datalens" {
+			service = ss
+		}
+	}
+
+	c.Assert(service, NotNil)
+	c.Assert(service.ServicePageID, Equals, uint(1252))
+	c.Assert(service.ZoneList(), DeepEquals, []string{ZONE_RU_A, ZONE_RU_B})
+	c.Assert(service.HasZone(ZONE_RU_B), Equals, true)
+	c.Assert(service.HasZone(ZONE_KZ_A), Equals, false)
+	c.Assert(service.IsPreview(), Equals, false)
+	c.Assert(services[0].ZoneList(), IsNil)
+
+	service = nil
+
+	c.Assert(service.ZoneList(), IsNil)
+	c.Assert(service.HasZone(ZONE_RU_A), Equals, false)
+	c.Assert(service.IsPreview(), Equals, false)
 }
 
 func (s *YCSSuite) TestGetIncidents(c *C) {
@@ -98,13 +193,16 @@ func (s *YCSSuite) TestGetIncident(c *C) {
 	c.Assert(incident.IsResolved(), Equals, true)
 	c.Assert(incident.Duration().String(), Equals, "7h2m0s")
 	c.Assert(incident.URL(LANG_EN), Equals, "https://status.yandex.cloud/en/incidents/972")
-	c.Assert(incident.ReportMarkdown(), Not(Equals), "")
+	c.Assert(incident.ReportMarkdown(), Matches, `(?s)\*\*Short description\.\*\*\n\n.*`)
+	c.Assert(incident.ReportMarkdown(), Matches, `(?s).*\n\n- Compute Cloud: .*\n- Virtual Private Cloud: .*`)
+	c.Assert(incident.ReportMarkdown(), Matches, `(?s).*\n!\[Volume of routing information in ru-central1-a\]\(https://.*`)
 	c.Assert(incident.RegionList(), DeepEquals, []string{"ru"})
 	c.Assert(incident.ZoneList(), DeepEquals, []string{"ru-central1-a"})
 	c.Assert(incident.ServiceList(), DeepEquals, []string{"Compute Cloud", "Virtual Private Cloud", "Network Load Balancer", "Managed Service for Kubernetes®", "Monitoring", "Managed Service for PostgreSQL", "Managed Service for ClickHouse®", "Managed Service for MongoDB", "Managed Service for Valkey™", "Data Processing", "SpeechKit", "Translate", "Vision OCR", "Managed Service for YDB", "Cloud Interconnect", "Data Transfer", "DataSphere", "Managed Service for Apache Kafka®", "Managed Service for Elasticsearch", "Application Load Balancer", "Cloud DNS", "Cloud CDN", "Cloud Logging", "Managed Service for Greenplum®", "Data Streams", "Managed Service for GitLab", "Cloud Desktop", "Yandex Query", "Managed Service for OpenSearch", "YandexGPT API", "Yandex Cloud Billing", "Yandex WebSQL", "Yandex Managed Service for Apache Airflow™", "Managed Service for Prometheus®", "SpeechSense", "Yandex MetaData Hub", "Foundation Models"})
 	c.Assert(incident.Comments.Get(0), NotNil)
 	c.Assert(incident.Comments.Get(0).Markdown(), Not(Equals), "")
 	c.Assert(incident.Comments.Get(5), IsNil)
+	c.Assert(incident.Comments.Latest(), Equals, incident.Comments.Get(0))
 
 	incident = nil
 	var comments Comments
@@ -117,6 +215,7 @@ func (s *YCSSuite) TestGetIncident(c *C) {
 	c.Assert(incident.ZoneList(), IsNil)
 	c.Assert(incident.ServiceList(), IsNil)
 	c.Assert(comments.Get(0), IsNil)
+	c.Assert(comments.Latest(), IsNil)
 	c.Assert(comments.Get(0).Markdown(), Equals, "")
 }
 
@@ -134,21 +233,1238 @@ func (s *YCSSuite) TestErrors(c *C) {
 	_, err = GetIncident(972, LANG_EN)
 	c.Assert(err, NotNil)
 	c.Assert(err.Error(), Equals, "Can't get incident 972: API returned non-ok status code 503")
+	c.Assert(errors.Is(err, ErrServerError), Equals, true)
+	c.Assert(errors.Is(err, ErrNotFound), Equals, false)
+
+	var apiErr *APIError
+
+	c.Assert(errors.As(err, &apiErr), Equals, true)
+	c.Assert(apiErr.StatusCode, Equals, 503)
+	c.Assert(apiErr.Endpoint, Equals, "/incidents/972")
+	c.Assert(apiErr.Body, Equals, "Service Unavailable")
+
+	SetUserAgent("rate-error", "1")
+
+	_, err = GetServices(LANG_RU)
+	c.Assert(err, NotNil)
+	c.Assert(errors.Is(err, ErrRateLimited), Equals, true)
 
 	SetUserAgent("data-error", "1")
 
 	_, err = GetIncident(972, LANG_EN)
 	c.Assert(err, NotNil)
 	c.Assert(err.Error(), Equals, "Can't get incident 972: Can't decode API response: invalid character 'F' looking for beginning of value")
+	c.Assert(errors.Is(err, ErrDecode), Equals, true)
 
 	SetUserAgent("", "")
 
-	apiURL = "http://127.0.0.1:9999"
+	_, err = GetIncident(2, LANG_EN)
+	c.Assert(err, NotNil)
+	c.Assert(errors.Is(err, ErrNotFound), Equals, true)
+	c.Assert(errors.As(err, &apiErr), Equals, true)
+	c.Assert(apiErr.Endpoint, Equals, "/incidents/2")
 
-	_, err = GetIncident(972, LANG_EN)
+	client := NewClient()
+	client.SetURL("http://127.0.0.1:9999")
+	client.SetRetryPolicy(RetryPolicy{})
+
+	_, err = client.GetIncident(972, LANG_EN)
 	c.Assert(err, NotNil)
+	c.Assert(errors.As(err, &apiErr), Equals, false)
+	c.Assert(errors.Is(err, ErrNotFound), Equals, false)
+}
+
+func (s *YCSSuite) TestCategories(c *C) {
+	services, err := GetServices(LANG_RU)
+	c.Assert(err, IsNil)
+
+	byCategory := services.ByCategory()
+
+	c.Assert(byCategory, HasLen, 6)
+	c.Assert(byCategory[CATEGORY_INFRASTRUCTURE], HasLen, 27)
+	c.Assert(byCategory[CATEGORY_DATA_PLATFORM], HasLen, 16)
+	c.Assert(byCategory[CATEGORY_RESOURCES], HasLen, 2)
+	c.Assert(byCategory[CATEGORY_OTHER], HasLen, 49)
+	c.Assert(services.Categories(), DeepEquals, []Category{0, 1, 2, 3, 4, 15})
+	c.Assert(Services{}.ByCategory(), IsNil)
+
+	incidents, err := GetIncidents(IncidentsRequest{})
+	c.Assert(err, IsNil)
+
+	incidentsByCategory := incidents.ByCategory(services)
+
+	c.Assert(incidentsByCategory, HasLen, 6)
+	c.Assert(incidentsByCategory[CATEGORY_INFRASTRUCTURE], HasLen, 10)
+	c.Assert(incidentsByCategory[CATEGORY_ML_AI], HasLen, 4)
+	c.Assert(incidentsByCategory[CATEGORY_OTHER], HasLen, 10)
+	c.Assert(Incidents{}.ByCategory(services), IsNil)
+
+	incidentsByCategory = Incidents{
+		nil,
+		{ID: 1, Services: Services{nil, {ID: 2}}},
+		{ID: 2, Services: Services{{ID: 74}}, Regions: Regions{nil, {Code: REGION_KZ}}},
+		{ID: 3, Services: Services{{ID: 9999, CategoryID: 4}}},
+	}.ByCategory(services)
+
+	c.Assert(incidentsByCategory, HasLen, 3)
+	c.Assert(incidentsByCategory[CATEGORY_INFRASTRUCTURE], HasLen, 1)
+	c.Assert(incidentsByCategory[CATEGORY_SERVERLESS], HasLen, 1)
+	c.Assert(incidentsByCategory[CATEGORY_ML_AI], HasLen, 1)
+	c.Assert(Incidents{{Services: Services{{ID: 2}}}}.ByCategory(nil), DeepEquals,
+		map[Category]Incidents{CATEGORY_OTHER: {{Services: Services{{ID: 2}}}}})
+
+	c.Assert(CATEGORY_SERVERLESS.IsKnown(), Equals, true)
+	c.Assert(Category(99).IsKnown(), Equals, false)
+	c.Assert(CATEGORY_SERVERLESS.Name(LANG_RU), Equals, "Бессерверные вычисления")
+	c.Assert(CATEGORY_SERVERLESS.Name("de"), Equals, "Serverless computing")
+	c.Assert(CATEGORY_SERVERLESS.String(), Equals, "Serverless computing")
+	c.Assert(Category(99).Name(LANG_EN), Equals, "Other services #99")
 
-	apiURL = "http://127.0.0.1:" + TEST_PORT
+	var service *Service
+	c.Assert(service.Category(), Equals, CATEGORY_OTHER)
+}
+
+func (s *YCSSuite) TestEnums(c *C) {
+	c.Assert(Lang(LANG_RU).String(), Equals, "ru")
+	c.Assert(Lang(LANG_RU).IsValid(), Equals, true)
+	c.Assert(Lang("de").IsValid(), Equals, false)
+	c.Assert(Lang(LANG_EN).Label(LANG_RU), Equals, "Английский")
+	c.Assert(Lang("de").Label(LANG_RU), Equals, "de")
+
+	c.Assert(RegionCode(REGION_KZ).String(), Equals, "kz")
+	c.Assert(RegionCode(REGION_KZ).IsValid(), Equals, true)
+	c.Assert(RegionCode(ZONE_KZ_A).IsValid(), Equals, false)
+	c.Assert(RegionCode(REGION_KZ).Label(LANG_EN), Equals, "Kazakhstan")
+	c.Assert(RegionCode(REGION_KZ).Label("de"), Equals, "Kazakhstan")
+
+	c.Assert(Status(STATUS_WITH_REPORT).String(), Equals, "withReport")
+	c.Assert(Status(STATUS_WITH_REPORT).IsValid(), Equals, true)
+	c.Assert(Status("closed").IsValid(), Equals, false)
+	c.Assert(Status(STATUS_OPEN).Label(LANG_RU), Equals, "Открыт")
+
+	c.Assert(CommentType(TYPE_UPDATE).String(), Equals, "update")
+	c.Assert(CommentType(TYPE_UPDATE).IsValid(), Equals, true)
+	c.Assert(CommentType("test").IsValid(), Equals, false)
+	c.Assert(CommentType(TYPE_INVESTIGATION).Label(LANG_EN), Equals, "Investigating")
+
+	c.Assert(LevelID(LEVEL_ID_MINOR).String(), Equals, LEVEL_MINOR)
+	c.Assert(LevelID(LEVEL_ID_UNAVAILABLE).String(), Equals, LEVEL_UNAVAILABLE)
+	c.Assert(LevelID(9).String(), Equals, "9")
+	c.Assert(LevelID(LEVEL_ID_UNAVAILABLE).IsValid(), Equals, true)
+	c.Assert(LevelID(9).IsValid(), Equals, false)
+	c.Assert(LevelID(LEVEL_ID_UNAVAILABLE).Label(LANG_RU), Equals, "Недоступность")
+
+	var level LevelID
+
+	c.Assert(level.UnmarshalText([]byte("critical")), NotNil)
+
+	data, _ := Lang(LANG_EN).MarshalText()
+	c.Assert(string(data), Equals, "en")
+	data, _ = RegionCode(REGION_RU).MarshalText()
+	c.Assert(string(data), Equals, "ru")
+	data, _ = Status(STATUS_OPEN).MarshalText()
+	c.Assert(string(data), Equals, "open")
+	data, _ = CommentType(TYPE_UPDATE).MarshalText()
+	c.Assert(string(data), Equals, "update")
+	data, _ = LevelID(LEVEL_ID_MINOR).MarshalText()
+	c.Assert(string(data), Equals, "1")
+
+	data, err := json.Marshal(map[string]any{"level": LevelID(LEVEL_ID_UNAVAILABLE), "status": Status(STATUS_OPEN)})
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `{"level":2,"status":"open"}`)
+
+	data, err = json.Marshal(map[LevelID]int{LEVEL_ID_MINOR: 1})
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `{"1":1}`)
+
+	c.Assert(level.UnmarshalJSON([]byte("null")), IsNil)
+	c.Assert(level, Equals, LevelID(0))
+	c.Assert(level.UnmarshalJSON([]byte(`"A"`)), NotNil)
+}
+
+func (s *YCSSuite) TestIncidentsRequestValidation(c *C) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	c.Assert(IncidentsRequest{}.Validate(), IsNil)
+	c.Assert(IncidentsRequest{
+		Lang: LANG_EN, Region: REGION_RU, Status: STATUS_OPEN,
+		From: from, To: to, Zones: []string{ZONE_RU_A, ZONE_RU_D},
+	}.Validate(), IsNil)
+	c.Assert(IncidentsRequest{Region: REGION_ALL, Zones: AllZones}.Validate(), IsNil)
+
+	var vErr *ValidationError
+
+	err := IncidentsRequest{Lang: "de"}.Validate()
+	c.Assert(errors.As(err, &vErr), Equals, true)
+	c.Assert(vErr.Field, Equals, "Lang")
+	c.Assert(errors.Is(err, ErrInvalidRequest), Equals, true)
+
+	err = IncidentsRequest{Region: ZONE_RU_A}.Validate()
+	c.Assert(err, ErrorMatches, `Invalid field Region: unknown region "ru-central1-a"`)
+
+	err = IncidentsRequest{Status: "closed"}.Validate()
+	c.Assert(err, ErrorMatches, `Invalid field Status: unknown status "closed"`)
+
+	err = IncidentsRequest{From: to, To: from}.Validate()
+	c.Assert(err, ErrorMatches, `Invalid field From: start date \(2024-02-01\) is after end date \(2024-01-01\)`)
+
+	err = IncidentsRequest{Page: -1}.Validate()
+	c.Assert(err, ErrorMatches, `Invalid field Page: .*`)
+
+	err = IncidentsRequest{PageSize: -1}.Validate()
+	c.Assert(err, ErrorMatches, `Invalid field PageSize: .*`)
+
+	err = IncidentsRequest{Zones: []string{"ru-central1-z"}}.Validate()
+	c.Assert(err, ErrorMatches, `Invalid field Zones: unknown zone "ru-central1-z"`)
+
+	err = IncidentsRequest{Region: REGION_RU, Zones: []string{ZONE_KZ_A}}.Validate()
+	c.Assert(err, ErrorMatches, `Invalid field Zones: zone "kz1-a" doesn't belong to region "ru"`)
+
+	_, err = GetIncidents(IncidentsRequest{Region: REGION_RU, Zones: []string{ZONE_KZ_A}})
+	c.Assert(errors.Is(err, ErrInvalidRequest), Equals, true)
+
+	c.Assert(ZoneRegion(ZONE_KZ_A), Equals, RegionCode(REGION_KZ))
+	c.Assert(ZoneRegion("unknown"), Equals, RegionCode(""))
+}
+
+func (s *YCSSuite) TestIncidentsFilter(c *C) {
+	incidents, err := GetIncidents(IncidentsRequest{})
+	c.Assert(err, IsNil)
+
+	c.Assert(incidents.Filter(), HasLen, 20)
+	c.Assert(incidents.Filter(ByLevel(LEVEL_ID_UNAVAILABLE)), HasLen, 6)
+	c.Assert(incidents.Filter(ByService("managed-postgresql")), HasLen, 4)
+	c.Assert(incidents.Filter(ByService("Managed Service for PostgreSQL")), HasLen, 4)
+	c.Assert(incidents.Filter(ByZone(ZONE_KZ_A)), HasLen, 2)
+	c.Assert(incidents.Filter(ByRegion(REGION_KZ)), HasLen, 2)
+	c.Assert(incidents.Filter(ByStatus(STATUS_OPEN)), HasLen, 1)
+	c.Assert(incidents.Filter(HasReport()), HasLen, 4)
+	c.Assert(incidents.Filter(
+		Between(
+			time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+		),
+	), HasLen, 5)
+
+	filtered := incidents.Filter(
+		ByLevel(LEVEL_ID_UNAVAILABLE),
+		ByZone(ZONE_RU_B),
+		LongerThan(30*time.Minute),
+		nil,
+	)
+
+	c.Assert(filtered, HasLen, 3)
+	c.Assert(filtered[0].ID, Equals, uint(1011))
+	c.Assert(Incidents{nil}.Filter(), HasLen, 0)
+
+	c.Assert(incidents.SortByStartDate()[0].ID, Equals, uint(930))
+	c.Assert(incidents.SortByDuration()[19].ID, Equals, uint(930))
+	c.Assert(incidents.SortByDuration()[0].ID, Equals, uint(965))
+	c.Assert(incidents.SortByLevel()[19].LevelID, Equals, LevelID(LEVEL_ID_UNAVAILABLE))
+	c.Assert(incidents.Reverse()[0].ID, Equals, uint(930))
+	c.Assert(incidents[0].ID, Equals, uint(1014))
+
+	withNil := append(Incidents{nil}, incidents...)
+
+	c.Assert(withNil.SortByStartDate(), HasLen, 20)
+	c.Assert(withNil.SortByStartDate()[0].ID, Equals, uint(930))
+	c.Assert(withNil.SortByDuration()[0].ID, Equals, uint(965))
+	c.Assert(withNil.SortByLevel()[19].LevelID, Equals, LevelID(LEVEL_ID_UNAVAILABLE))
+	c.Assert(withNil[0], IsNil)
+	c.Assert(Incidents{nil, nil}.SortByLevel(), HasLen, 0)
+
+	open := &Incident{StartDate: Date{time.Now().Add(-time.Hour)}}
+
+	c.Assert(Incidents{open}.Filter(LongerThan(30*time.Minute)), HasLen, 1)
+}
+
+func (s *YCSSuite) TestAvailability(c *C) {
+	day := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) Date { return Date{day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)} }
+
+	svcA := &Service{Name: "A"}
+	svcB := &Service{Name: "B"}
+	zoneA := Regions{{Code: REGION_RU, Zones: Zones{{ID: ZONE_RU_A}}}}
+	ru := Regions{{Code: REGION_RU}}
+	kz := Regions{{Code: REGION_KZ}}
+
+	incidents := Incidents{
+		{ID: 1, LevelID: LEVEL_ID_UNAVAILABLE, StartDate: at(10, 0), EndDate: at(11, 0), Services: Services{svcA, svcB}, Regions: zoneA},
+		{ID: 2, LevelID: LEVEL_ID_MINOR, StartDate: at(10, 30), EndDate: at(12, 0), Services: Services{svcA}, Regions: zoneA},
+		{ID: 3, LevelID: LEVEL_ID_MINOR, StartDate: at(11, 30), EndDate: at(12, 30), Services: Services{svcA}, Regions: ru},
+		{ID: 4, LevelID: LEVEL_ID_UNAVAILABLE, StartDate: at(23, 0), Services: Services{svcA}, Regions: ru},
+		{ID: 5, LevelID: LEVEL_ID_UNAVAILABLE, StartDate: at(-2, 0), EndDate: at(-1, 0), Services: Services{svcB}, Regions: ru},
+		{ID: 6, LevelID: LEVEL_ID_UNAVAILABLE, StartDate: at(-1, 0), EndDate: at(1, 0), Services: Services{svcB}, Regions: ru},
+		{ID: 7, LevelID: LEVEL_ID_UNAVAILABLE, StartDate: at(2, 0), EndDate: at(3, 0), Services: Services{svcA}, Regions: kz},
+		{ID: 8, LevelID: LEVEL_ID_MINOR, StartDate: at(4, 0), EndDate: at(5, 0), Services: Services{svcA}},
+	}
+
+	report := incidents.Availability(day, day.Add(24*time.Hour), AvailabilityOptions{
+		Now:         day.Add(23*time.Hour + 30*time.Minute),
+		MinorWeight: 0.5,
+	})
+
+	c.Assert(report.Services, HasLen, 3)
+
+	a := report.Service("A", REGION_RU)
+
+	c.Assert(a.Incidents, Equals, 4)
+	c.Assert(a.Unavailable, Equals, 90*time.Minute)
+	c.Assert(a.Minor, Equals, 90*time.Minute)
+	c.Assert(a.Downtime(), Equals, 135*time.Minute)
+	c.Assert(a.Percent(), Equals, 90.625)
+	c.Assert(a.MeetsSLA(90), Equals, true)
+	c.Assert(a.MeetsSLA(99.95), Equals, false)
+
+	a = report.Service("A", REGION_KZ)
+
+	c.Assert(a.Incidents, Equals, 1)
+	c.Assert(a.Unavailable, Equals, time.Hour)
+	c.Assert(a.Minor, Equals, time.Duration(0))
+
+	a = report.Service("A", "")
+
+	c.Assert(a.Incidents, Equals, 1)
+	c.Assert(a.Minor, Equals, time.Hour)
+
+	b := report.Service("B", REGION_RU)
+
+	c.Assert(b.Incidents, Equals, 2)
+	c.Assert(b.Unavailable, Equals, 2*time.Hour)
+	c.Assert(b.Minor, Equals, time.Duration(0))
+	c.Assert(report.Service("B", REGION_KZ).Incidents, Equals, 0)
+
+	z := report.Zone(ZONE_RU_A)
+
+	c.Assert(z.Unavailable, Equals, time.Hour)
+	c.Assert(z.Minor, Equals, time.Hour)
+	c.Assert(z.Downtime(), Equals, 90*time.Minute)
+
+	c.Assert(report.Service("C", REGION_RU).Percent(), Equals, 100.0)
+	c.Assert(report.Zone(ZONE_KZ_A).Percent(), Equals, 100.0)
+
+	report = incidents.Availability(day, day, AvailabilityOptions{})
+
+	c.Assert(report.Services, HasLen, 0)
+
+	report = Incidents{
+		{ID: 9, LevelID: LEVEL_ID_UNAVAILABLE, StartDate: at(-2, 0), Services: Services{svcA}},
+	}.Availability(day, day.Add(24*time.Hour), AvailabilityOptions{Now: at(-1, 0).Time})
+
+	c.Assert(report.Services, HasLen, 0)
+	c.Assert(report.Service("A", "").Percent(), Equals, 100.0)
+
+	svcC := &Service{Name: "C", Zones: Zones{nil, {ID: ZONE_KZ_A}, {ID: "unknown", Region: &Region{Code: REGION_KZ}}}}
+	svcD := &Service{Name: "D", InstallationCode: REGION_RU}
+
+	report = Incidents{
+		{ID: 10, LevelID: LEVEL_ID_UNAVAILABLE, StartDate: at(1, 0), EndDate: at(2, 0), Services: Services{svcA, svcC, svcD, svcA, nil}, Regions: append(ru, kz...)},
+	}.Availability(day, day.Add(24*time.Hour), AvailabilityOptions{})
+
+	c.Assert(report.Service("A", REGION_RU).Incidents, Equals, 1)
+	c.Assert(report.Service("A", REGION_KZ).Incidents, Equals, 1)
+	c.Assert(report.Service("C", REGION_KZ).Incidents, Equals, 1)
+	c.Assert(report.Service("C", REGION_RU).Incidents, Equals, 0)
+	c.Assert(report.Service("D", REGION_RU).Incidents, Equals, 1)
+	c.Assert(report.Service("D", REGION_KZ).Incidents, Equals, 0)
+
+	var nilReport *AvailabilityReport
+	var nilAvailability *Availability
+
+	c.Assert(nilReport.Service("A", ""), IsNil)
+	c.Assert(nilReport.Zone(ZONE_RU_A), IsNil)
+	c.Assert(nilAvailability.Downtime(), Equals, time.Duration(0))
+	c.Assert(nilAvailability.Percent(), Equals, 0.0)
+	c.Assert(nilAvailability.MeetsSLA(0), Equals, false)
+
+	merged := intervals{
+		{at(5, 0).Time, at(6, 0).Time},
+		{at(1, 0).Time, at(2, 0).Time},
+		{at(1, 30).Time, at(1, 45).Time},
+		{at(2, 0).Time, at(3, 0).Time},
+	}.merge()
+
+	c.Assert(merged, HasLen, 2)
+	c.Assert(merged.duration(), Equals, 3*time.Hour)
+	c.Assert(intervals{}.merge(), IsNil)
+
+	rest := intervals{{at(0, 0).Time, at(10, 0).Time}}.subtract(merged)
+
+	c.Assert(rest, HasLen, 3)
+	c.Assert(rest.duration(), Equals, 7*time.Hour)
+}
+
+func (s *YCSSuite) TestFeeds(c *C) {
+	incidents, err := GetIncidents(IncidentsRequest{})
+	c.Assert(err, IsNil)
+
+	data, err := incidents.RSS(FeedOptions{Lang: LANG_EN, SelfURL: "https://domain.com/feed.rss"})
+	c.Assert(err, IsNil)
+	c.Assert(strings.HasPrefix(string(data), xml.Header), Equals, true)
+
+	rss := &rssFeed{}
+	c.Assert(xml.Unmarshal(data, rss), IsNil)
+
+	c.Assert(rss.Version, Equals, "2.0")
+	c.Assert(rss.Channel.Title, Equals, "Yandex Cloud incidents")
+	c.Assert(rss.Channel.Link, Equals, "https://status.yandex.cloud/en")
+	c.Assert(rss.Channel.Language, Equals, "en")
+	c.Assert(rss.Channel.Items, HasLen, 20)
+	c.Assert(strings.Contains(string(data), `<atom:link href="https://domain.com/feed.rss" rel="self" type="application/rss+xml"></atom:link>`), Equals, true)
+
+	for idx := 1; idx < len(rss.Channel.Items); idx++ {
+		prev, _ := time.Parse(time.RFC1123Z, rss.Channel.Items[idx-1].PubDate)
+		cur, _ := time.Parse(time.RFC1123Z, rss.Channel.Items[idx].PubDate)
+		c.Assert(prev.Before(cur), Equals, false)
+	}
+
+	item := rss.Channel.Items[0]
+
+	c.Assert(item.GUID.IsPermaLink, Equals, true)
+	c.Assert(item.GUID.Value, Equals, item.Link)
+	c.Assert(item.Link, Matches, `https://status.yandex.cloud/en/incidents/\d+`)
+	c.Assert(rss.Channel.LastBuildDate, Equals, item.PubDate)
+
+	data, err = incidents.RSS(FeedOptions{Regions: []RegionCode{REGION_KZ}, Limit: 1})
+	c.Assert(err, IsNil)
+	rss = &rssFeed{}
+	c.Assert(xml.Unmarshal(data, rss), IsNil)
+	c.Assert(rss.Channel.Items, HasLen, 1)
+	c.Assert(rss.Channel.Link, Equals, "https://status.yandex.cloud/ru")
+
+	data, err = incidents.Atom(FeedOptions{Lang: LANG_EN, Services: []string{"managed-postgresql"}})
+	c.Assert(err, IsNil)
+
+	atom := &atomFeed{}
+	c.Assert(xml.Unmarshal(data, atom), IsNil)
+
+	c.Assert(atom.XMLName.Space, Equals, "http://www.w3.org/2005/Atom")
+	c.Assert(atom.ID, Equals, "https://status.yandex.cloud/en")
+	c.Assert(atom.Author.Name, Equals, "Yandex Cloud")
+	c.Assert(atom.Entries, HasLen, 4)
+	c.Assert(atom.Updated, Equals, atom.Entries[0].Updated)
+
+	for _, e := range atom.Entries {
+		c.Assert(e.Link.Href, Equals, e.ID)
+		c.Assert(e.Title, Matches, `\[.+\] .+`)
+	}
+
+	data, err = incidents.Atom(FeedOptions{Zones: []string{"unknown"}, SelfURL: "https://domain.com/feed.atom"})
+	c.Assert(err, IsNil)
+	atom = &atomFeed{}
+	c.Assert(xml.Unmarshal(data, atom), IsNil)
+	c.Assert(atom.Entries, HasLen, 0)
+	c.Assert(atom.ID, Equals, "https://domain.com/feed.atom")
+	c.Assert(atom.Links, HasLen, 2)
+
+	incident, err := GetIncident(972, LANG_EN)
+	c.Assert(err, IsNil)
+
+	data, err = Incidents{incident}.Atom(FeedOptions{Lang: LANG_EN, PerComment: true})
+	c.Assert(err, IsNil)
+	atom = &atomFeed{}
+	c.Assert(xml.Unmarshal(data, atom), IsNil)
+	c.Assert(atom.Entries, HasLen, len(incident.Comments))
+
+	latest := incident.Comments.Latest()
+
+	c.Assert(atom.Entries[0].ID, Equals, fmt.Sprintf("https://status.yandex.cloud/en/incidents/972#comment-%d", latest.ID))
+	c.Assert(atom.Entries[0].Content.Type, Equals, "html")
+	c.Assert(atom.Entries[0].Content.Value, Equals, latest.Content)
+
+	data, err = Incidents{incident}.RSS(FeedOptions{Lang: LANG_EN})
+	c.Assert(err, IsNil)
+	rss = &rssFeed{}
+	c.Assert(xml.Unmarshal(data, rss), IsNil)
+	c.Assert(rss.Channel.Items, HasLen, 1)
+	c.Assert(rss.Channel.Items[0].Description, Equals, incident.Report)
+	c.Assert(rss.Channel.Items[0].Categories, DeepEquals, uniqueStrings(incident.ServiceList()))
+
+	data, err = Incidents{incident}.RSS(FeedOptions{PerComment: true})
+	c.Assert(err, IsNil)
+	rss = &rssFeed{}
+	c.Assert(xml.Unmarshal(data, rss), IsNil)
+	c.Assert(rss.Channel.Items[0].GUID.IsPermaLink, Equals, false)
+
+	c.Assert(firstDate(Date{}, Date{}).IsZero(), Equals, true)
+}
+
+func (s *YCSSuite) TestExport(c *C) {
+	incidents, err := GetIncidents(IncidentsRequest{})
+	c.Assert(err, IsNil)
+
+	incident := incidents[0]
+	c.Assert(incident.ID, Equals, uint(1014))
+
+	var buf bytes.Buffer
+
+	c.Assert(incidents.CSV(&buf, ExportOptions{Lang: LANG_EN}), IsNil)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+
+	c.Assert(err, IsNil)
+	c.Assert(records, HasLen, len(incidents)+1)
+	c.Assert(records[0], DeepEquals, []string{
+		"id", "title", "status", "level", "start", "end", "duration",
+		"regions", "zones", "services", "report_url",
+	})
+	c.Assert(records[1], DeepEquals, []string{
+		"1014", incident.Title, "open", "Minor", "2024-12-23T03:50:00Z",
+		"2024-12-23T06:15:00Z", "8700", "ru", "ru-central1-a|ru-central1-b",
+		"Compute Cloud|Virtual Private Cloud", "",
+	})
+
+	buf.Reset()
+
+	c.Assert(incidents[:1].CSV(&buf, ExportOptions{
+		Columns:   []ExportColumn{COLUMN_ID, COLUMN_SERVICES, COLUMN_ZONES},
+		Delimiter: ';',
+		NoHeader:  true,
+		Exploded:  true,
+	}), IsNil)
+
+	c.Assert(buf.String(), Equals, "1014;Compute Cloud;ru-central1-a\n"+
+		"1014;Compute Cloud;ru-central1-b\n"+
+		"1014;Virtual Private Cloud;ru-central1-a\n"+
+		"1014;Virtual Private Cloud;ru-central1-b\n")
+
+	open := &Incident{ID: 2, Title: "Test", Status: STATUS_OPEN, StartDate: incident.StartDate}
+	reported := &Incident{ID: 3, IsReportPublished: true, Regions: Regions{{Code: REGION_KZ}}}
+
+	buf.Reset()
+
+	c.Assert(Incidents{open, nil, reported}.JSONL(&buf, ExportOptions{Lang: LANG_EN}), IsNil)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+	c.Assert(lines, HasLen, 2)
+	c.Assert(lines[0], Equals, `{"id":2,"title":"Test","status":"open","level":"0",`+
+		`"start":"2024-12-23T03:50:00Z","end":null,"duration":null,"regions":[],`+
+		`"zones":[],"services":[],"report_url":""}`)
+	c.Assert(lines[1], Equals, `{"id":3,"title":"","status":"","level":"0",`+
+		`"start":null,"end":null,"duration":null,"regions":["kz"],`+
+		`"zones":[],"services":[],"report_url":"https://status.yandex.cloud/en/incidents/3"}`)
+
+	buf.Reset()
+
+	c.Assert(Incidents{incident, reported}.JSONL(&buf, ExportOptions{
+		Columns:  []ExportColumn{COLUMN_ID, COLUMN_REGIONS, COLUMN_ZONES, COLUMN_SERVICES},
+		Exploded: true,
+	}), IsNil)
+
+	lines = strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+	c.Assert(lines, HasLen, 5)
+	c.Assert(lines[0], Equals, `{"id":1014,"regions":"ru","zones":"ru-central1-a","services":"Compute Cloud"}`)
+	c.Assert(lines[4], Equals, `{"id":3,"regions":"kz","zones":null,"services":null}`)
+
+	for _, line := range lines {
+		c.Assert(json.Valid([]byte(line)), Equals, true)
+	}
+
+	c.Assert(incidents.CSV(&buf, ExportOptions{Columns: []ExportColumn{"unknown"}}), NotNil)
+	c.Assert(incidents.JSONL(&buf, ExportOptions{Columns: []ExportColumn{"unknown"}}), NotNil)
+	c.Assert(incidents.CSV(&buf, ExportOptions{Delimiter: '"'}), NotNil)
+	c.Assert(ExportColumn("unknown").IsValid(), Equals, false)
+	c.Assert(COLUMN_REPORT_URL.IsValid(), Equals, true)
+}
+
+func (s *YCSSuite) TestICS(c *C) {
+	incident, err := GetIncident(972, LANG_EN)
+	c.Assert(err, IsNil)
+
+	open := &Incident{
+		ID:        2,
+		Title:     "Test; incident, with\\special chars",
+		StartDate: Date{time.Now().Add(-time.Hour)},
+	}
+
+	noID := &Incident{Title: "Test", StartDate: Date{time.Now().Add(-time.Hour)}}
+	generated := time.Now().Truncate(time.Second)
+	data := string(Incidents{incident, open, &Incident{ID: 3}, nil, noID}.ICSLang(LANG_EN))
+
+	c.Assert(strings.HasPrefix(data, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"), Equals, true)
+	c.Assert(strings.HasSuffix(data, "END:VEVENT\r\nEND:VCALENDAR\r\n"), Equals, true)
+	c.Assert(strings.Count(data, "BEGIN:VEVENT\r\n"), Equals, 3)
+	c.Assert(strings.Count(data, "\r\nURL:"), Equals, 2)
+	c.Assert(strings.Count(data, "\r\nDESCRIPTION:"), Equals, 2)
+
+	stamps := regexp.MustCompile(`\r\nDTSTAMP:(\w+)\r\n`).FindAllStringSubmatch(data, -1)
+
+	c.Assert(stamps, HasLen, 3)
+
+	for _, m := range stamps {
+		stamp, err := time.Parse(icsDateFormat, m[1])
+		c.Assert(err, IsNil)
+		c.Assert(stamp.Before(generated) || stamp.After(time.Now()), Equals, false, Commentf("Invalid DTSTAMP %s", m[1]))
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(data, "\r\n"), "\r\n") {
+		c.Assert(len(line) <= 75, Equals, true, Commentf("Line is too long: %q", line))
+		c.Assert(utf8.ValidString(line), Equals, true, Commentf("Line is invalid: %q", line))
+	}
+
+	unfolded := strings.ReplaceAll(data, "\r\n ", "")
+
+	c.Assert(strings.Contains(unfolded, "\r\nUID:incident-972@status.yandex.cloud\r\n"), Equals, true)
+	c.Assert(strings.Contains(unfolded, "\r\nDTSTART:20241016T114000Z\r\n"), Equals, true)
+	c.Assert(strings.Contains(unfolded, "\r\nDTEND:20241016T184200Z\r\n"), Equals, true)
+	c.Assert(strings.Contains(unfolded, "\r\nSUMMARY:"+icsReplacer.Replace(incident.Title)+"\r\n"), Equals, true)
+	c.Assert(strings.Contains(unfolded, "\r\nLOCATION:"+strings.Join(uniqueStrings(incident.ZoneList()), "\\, ")+"\r\n"), Equals, true)
+	c.Assert(strings.Contains(unfolded, "\r\nURL:https://status.yandex.cloud/en/incidents/972\r\n"), Equals, true)
+	c.Assert(strings.Contains(unfolded, "\r\nDESCRIPTION:https://status.yandex.cloud/en/incidents/972\\n\\n"), Equals, true)
+	c.Assert(strings.Contains(unfolded, "\r\nSTATUS:CONFIRMED\r\n"), Equals, true)
+
+	c.Assert(strings.Contains(unfolded, "\r\nSUMMARY:[Open] Test\\; incident\\, with\\\\special chars\r\n"), Equals, true)
+	c.Assert(strings.Contains(unfolded, "\r\nSTATUS:TENTATIVE\r\n"), Equals, true)
+	c.Assert(strings.Contains(unfolded, "\r\nDESCRIPTION:https://status.yandex.cloud/en/incidents/2\r\n"), Equals, true)
+
+	data = string(Incidents{incident}.ICS())
+
+	c.Assert(strings.Contains(data, "\r\nURL:https://status.yandex.cloud/ru/incidents/972\r\n"), Equals, true)
+
+	data = string(Incidents{}.ICS())
+
+	c.Assert(data, Equals, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//ESSENTIAL KAOS//YCS.go//EN\r\n"+
+		"CALSCALE:GREGORIAN\r\nMETHOD:PUBLISH\r\nX-WR-CALNAME:Yandex Cloud incidents\r\nEND:VCALENDAR\r\n")
+
+	var buf bytes.Buffer
+
+	writeICSLine(&buf, "SUMMARY", strings.Repeat("Ё", 80))
+	lines := strings.Split(buf.String(), "\r\n")
+
+	c.Assert(lines, HasLen, 4)
+	c.Assert(lines[0], Equals, "SUMMARY:"+strings.Repeat("Ё", 33))
+	c.Assert(lines[1], Equals, " "+strings.Repeat("Ё", 37))
+	c.Assert(lines[2], Equals, " "+strings.Repeat("Ё", 10))
+}
+
+func (s *YCSSuite) TestEnumsUnmarshalText(c *C) {
+	unmarshal := func(v encoding.TextUnmarshaler, data string) any {
+		c.Assert(v.UnmarshalText([]byte(data)), IsNil, Commentf("Input: %q", data))
+		return reflect.ValueOf(v).Elem().Interface()
+	}
+
+	testCases := []struct {
+		value    func() encoding.TextUnmarshaler
+		input    string
+		expected any
+	}{
+		{func() encoding.TextUnmarshaler { return new(Lang) }, "en", Lang(LANG_EN)},
+		{func() encoding.TextUnmarshaler { return new(Lang) }, "EN", Lang(LANG_EN)},
+		{func() encoding.TextUnmarshaler { return new(Lang) }, "De", Lang("De")},
+		{func() encoding.TextUnmarshaler { return new(RegionCode) }, "ru", RegionCode(REGION_RU)},
+		{func() encoding.TextUnmarshaler { return new(RegionCode) }, "RU", RegionCode(REGION_RU)},
+		{func() encoding.TextUnmarshaler { return new(RegionCode) }, "All", RegionCode(REGION_ALL)},
+		{func() encoding.TextUnmarshaler { return new(Status) }, "resolved", Status(STATUS_RESOLVED)},
+		{func() encoding.TextUnmarshaler { return new(Status) }, "OPEN", Status(STATUS_OPEN)},
+		{func() encoding.TextUnmarshaler { return new(Status) }, "withreport", Status(STATUS_WITH_REPORT)},
+		{func() encoding.TextUnmarshaler { return new(Status) }, "Closed", Status("Closed")},
+		{func() encoding.TextUnmarshaler { return new(CommentType) }, "resolved", CommentType(TYPE_RESOLVED)},
+		{func() encoding.TextUnmarshaler { return new(CommentType) }, "Investigation", CommentType(TYPE_INVESTIGATION)},
+		{func() encoding.TextUnmarshaler { return new(CommentType) }, "UPDATE", CommentType(TYPE_UPDATE)},
+		{func() encoding.TextUnmarshaler { return new(LevelID) }, "unavailable", LevelID(LEVEL_ID_UNAVAILABLE)},
+		{func() encoding.TextUnmarshaler { return new(LevelID) }, "Minor", LevelID(LEVEL_ID_MINOR)},
+		{func() encoding.TextUnmarshaler { return new(LevelID) }, "UNAVAILABLE", LevelID(LEVEL_ID_UNAVAILABLE)},
+		{func() encoding.TextUnmarshaler { return new(LevelID) }, "2", LevelID(LEVEL_ID_UNAVAILABLE)},
+	}
+
+	for _, tc := range testCases {
+		c.Assert(unmarshal(tc.value(), tc.input), Equals, tc.expected, Commentf("Input: %q", tc.input))
+	}
+}
+
+func (s *YCSSuite) TestStats(c *C) {
+	incidents, err := GetIncidents(IncidentsRequest{})
+	c.Assert(err, IsNil)
+
+	report := incidents.Stats()
+
+	c.Assert(report.Total.Count, Equals, 20)
+	c.Assert(report.Total.Unavailable, Equals, 6)
+	c.Assert(report.Total.Resolved, Equals, 20)
+	c.Assert(report.Total.Reports, Equals, 4)
+	c.Assert(report.Total.MTTR.String(), Equals, "10h26m24.55s")
+	c.Assert(report.Total.MTTD.String(), Equals, "6h17m13.44535s")
+	c.Assert(report.Total.MeanReport.String(), Equals, "125h45m29.9115s")
+	c.Assert(report.Total.MaxDuration.String(), Equals, "115h30m0s")
+
+	c.Assert(report.Regions[REGION_KZ].Count, Equals, 2)
+	c.Assert(report.Regions[REGION_RU].Count, Equals, 20)
+	c.Assert(report.Zones[ZONE_KZ_A].Count, Equals, 2)
+	c.Assert(report.Services[REGION_RU]["Compute Cloud"].Count, Equals, 5)
+	c.Assert(report.Service("Compute Cloud", REGION_RU).Count, Equals, 5)
+	c.Assert(report.Service("Compute Cloud", REGION_KZ).Count, Equals, 0)
+	c.Assert(report.Months["2024-12"].Count, Equals, 5)
+	c.Assert(report.Months["2024-11"].Count, Equals, 4)
+	c.Assert(report.Months["2024-10"].Count, Equals, 6)
+	c.Assert(report.Months["2024-09"].Count, Equals, 5)
+
+	data, err := json.Marshal(report)
+
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(data), `"regions":{"kz":{"count":2`), Equals, true)
+
+	report = Incidents{nil, &Incident{ReportPublishedTime: Date{time.Now()}}}.Stats()
+
+	c.Assert(report.Total.Count, Equals, 1)
+	c.Assert(report.Total.Resolved, Equals, 0)
+	c.Assert(report.Months, HasLen, 0)
+	c.Assert(report.Services, HasLen, 0)
+
+	ru := Regions{{Code: REGION_RU}}
+	kz := Regions{{Code: REGION_KZ}}
+	svc := &Service{Name: "A"}
+
+	report = Incidents{
+		{ID: 1, LevelID: LEVEL_ID_UNAVAILABLE, Services: Services{svc}, Regions: ru},
+		{ID: 2, LevelID: LEVEL_ID_MINOR, Services: Services{svc}, Regions: kz},
+		{ID: 3, LevelID: LEVEL_ID_MINOR, Services: Services{svc}, Regions: append(ru, kz...)},
+		{ID: 4, LevelID: LEVEL_ID_MINOR, Services: Services{{Name: "A", Zones: Zones{{ID: ZONE_KZ_A}}}}, Regions: append(ru, kz...)},
+		{ID: 5, LevelID: LEVEL_ID_MINOR, Services: Services{svc}},
+	}.Stats()
+
+	c.Assert(report.Services, HasLen, 3)
+	c.Assert(report.Service("A", REGION_RU).Count, Equals, 2)
+	c.Assert(report.Service("A", REGION_RU).Unavailable, Equals, 1)
+	c.Assert(report.Service("A", REGION_KZ).Count, Equals, 3)
+	c.Assert(report.Service("A", REGION_KZ).Unavailable, Equals, 0)
+	c.Assert(report.Service("A", "").Count, Equals, 1)
+
+	var nilReport *StatsReport
+	c.Assert(nilReport.Service("A", REGION_RU), IsNil)
+}
+
+func (s *YCSSuite) TestDiff(c *C) {
+	prev := Incidents{
+		{ID: 1, Status: STATUS_OPEN, LevelID: LEVEL_ID_MINOR, Comments: Comments{{ID: 10}}},
+		{ID: 2, Status: STATUS_RESOLVED, LevelID: LEVEL_ID_MINOR},
+		{ID: 3, Status: STATUS_OPEN, LevelID: LEVEL_ID_MINOR},
+	}
+
+	cur := Incidents{
+		{ID: 1, Status: STATUS_RESOLVED, LevelID: LEVEL_ID_UNAVAILABLE, Comments: Comments{{ID: 11}, {ID: 10}}},
+		{ID: 2, Status: STATUS_RESOLVED, LevelID: LEVEL_ID_MINOR, IsReportPublished: true},
+		{ID: 4, Status: STATUS_RESOLVED, LevelID: LEVEL_ID_MINOR},
+		nil,
+	}
+
+	events := DiffIncidents(append(prev, nil), cur)
+
+	c.Assert(events, HasLen, 6)
+	c.Assert(events[0].Type, Equals, EVENT_INCIDENT_UPDATED)
+	c.Assert(events[0].Comments, HasLen, 1)
+	c.Assert(events[0].Comments[0].ID, Equals, uint(11))
+	c.Assert(events[0].Previous, Equals, prev[0])
+	c.Assert(events[1].Type, Equals, EVENT_LEVEL_CHANGED)
+	c.Assert(events[2].Type, Equals, EVENT_INCIDENT_RESOLVED)
+	c.Assert(events[3].Type, Equals, EVENT_REPORT_PUBLISHED)
+	c.Assert(events[3].Incident.ID, Equals, uint(2))
+	c.Assert(events[4].Type, Equals, EVENT_INCIDENT_OPENED)
+	c.Assert(events[4].Incident.ID, Equals, uint(4))
+	c.Assert(events[5].Type, Equals, EVENT_INCIDENT_RESOLVED)
+
+	c.Assert(DiffIncidents(cur, cur), HasLen, 0)
+
+	svcRU := &Service{ID: 1, InstallationCode: REGION_RU}
+	svcKZ := &Service{ID: 1, InstallationCode: REGION_KZ}
+	svc2 := &Service{ID: 2, InstallationCode: REGION_RU}
+
+	events = DiffServices(Services{svcRU, svc2, nil}, Services{svcRU, svcKZ, nil})
+
+	c.Assert(events, HasLen, 2)
+	c.Assert(events[0].Type, Equals, EVENT_SERVICE_ADDED)
+	c.Assert(events[0].Service, Equals, svcKZ)
+	c.Assert(events[1].Type, Equals, EVENT_SERVICE_REMOVED)
+	c.Assert(events[1].Service, Equals, svc2)
+
+	c.Assert(Comments{{ID: 1}, nil}.Since(Comments{nil}), HasLen, 1)
+	c.Assert(Comments{{ID: 2}, nil, {ID: 1}}.IDs(), DeepEquals, []uint{2, 1})
+
+	changes := IncidentChanges(prev[0], cur[0])
+
+	c.Assert(changes, HasLen, 3)
+	c.Assert(changes[0], DeepEquals, Change{"status", Status(STATUS_OPEN), Status(STATUS_RESOLVED)})
+	c.Assert(changes[1], DeepEquals, Change{"levelId", LevelID(LEVEL_ID_MINOR), LevelID(LEVEL_ID_UNAVAILABLE)})
+	c.Assert(changes[2], DeepEquals, Change{"comments", []uint{10}, []uint{11, 10}})
+
+	c.Assert(IncidentChanges(cur[0], cur[0]), HasLen, 0)
+	c.Assert(IncidentChanges(nil, nil), HasLen, 0)
+	c.Assert(IncidentChanges(nil, &Incident{Title: "Test"}), DeepEquals, []Change{{"title", "", "Test"}})
+}
+
+func (s *YCSSuite) TestWatcher(c *C) {
+	w := NewWatcher(nil, WatcherOptions{Services: true})
+
+	c.Assert(w.client, Equals, defaultClient)
+	c.Assert(w.opts.Interval, Equals, time.Minute)
+	c.Assert(w.Poll(context.Background()), HasLen, 0)
+	c.Assert(w.Poll(context.Background()), HasLen, 0)
+	c.Assert(w.services, HasLen, 104)
+
+	w = NewWatcher(defaultClient, WatcherOptions{EmitInitial: true, Interval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var events []Event
+
+	err := w.Run(ctx, func(e Event) { events = append(events, e) })
+
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+	c.Assert(events, HasLen, 43)
+	c.Assert(events[0].Type, Equals, EVENT_INCIDENT_OPENED)
+
+	client := NewClient()
+	client.SetURL("http://127.0.0.1:" + TEST_PORT)
+	client.SetRetryPolicy(RetryPolicy{})
+	client.SetUserAgent("http-error", "1")
+
+	w = NewWatcher(client, WatcherOptions{Interval: 10 * time.Millisecond})
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var errorEvents int
+
+	for e := range w.Watch(ctx) {
+		c.Assert(e.Type, Equals, EVENT_ERROR)
+		c.Assert(e.Err, NotNil)
+		errorEvents++
+	}
+
+	c.Assert(errorEvents > 0, Equals, true)
+
+	client.SetUserAgent("", "")
+	w = NewWatcher(client, WatcherOptions{Services: true})
+
+	c.Assert(w.Poll(context.Background()), HasLen, 0)
+
+	w.services = append(w.services, &Service{ID: 999})
+	events = w.Poll(context.Background())
+
+	c.Assert(events, HasLen, 1)
+	c.Assert(events[0].Type, Equals, EVENT_SERVICE_REMOVED)
+	c.Assert(events[0].Service.ID, Equals, uint(999))
+
+	client.SetUserAgent("http-error", "1")
+	w.opts.Request.Lang = "de"
+	c.Assert(w.Poll(context.Background())[0].Type, Equals, EVENT_ERROR)
+
+	w.opts.Request.Lang = ""
+	c.Assert(w.Poll(context.Background())[0].Type, Equals, EVENT_ERROR)
+
+	failServices := &atomic.Bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/services" && failServices.Load():
+			rw.WriteHeader(http.StatusInternalServerError)
+		case r.URL.Path == "/services":
+			handlerServices(rw, r)
+		default:
+			handlerIncidents(rw, r)
+		}
+	}))
+
+	defer server.Close()
+
+	client = NewClient()
+	client.SetURL(server.URL)
+	client.SetRetryPolicy(RetryPolicy{})
+	failServices.Store(true)
+
+	w = NewWatcher(client, WatcherOptions{Services: true})
+	events = w.Poll(context.Background())
+
+	c.Assert(events, HasLen, 1)
+	c.Assert(events[0].Type, Equals, EVENT_ERROR)
+	c.Assert(w.incidents, HasLen, 20)
+
+	w.incidents = w.incidents[1:]
+	events = w.Poll(context.Background())
+
+	c.Assert(events, HasLen, 2)
+	c.Assert(events[0].Type, Equals, EVENT_INCIDENT_OPENED)
+	c.Assert(events[1].Type, Equals, EVENT_ERROR)
+
+	events = w.Poll(context.Background())
+
+	c.Assert(events, HasLen, 1)
+	c.Assert(events[0].Type, Equals, EVENT_ERROR)
+
+	failServices.Store(false)
+
+	c.Assert(w.Poll(context.Background()), HasLen, 0)
+	c.Assert(w.Poll(context.Background()), HasLen, 0)
+	c.Assert(w.services, HasLen, 104)
+
+	var nilWatcher *Watcher
+
+	c.Assert(nilWatcher.Poll(context.Background()), IsNil)
+	c.Assert(nilWatcher.Run(context.Background(), nil), NotNil)
+	c.Assert(w.Run(context.Background(), nil), NotNil)
+}
+
+func (s *YCSSuite) TestStateStore(c *C) {
+	incidents, err := GetIncidents(IncidentsRequest{})
+	c.Assert(err, IsNil)
+
+	stateFile := c.MkDir() + "/state.json"
+	fileStore, err := NewFileStateStore(stateFile)
+	c.Assert(err, IsNil)
+
+	for _, store := range []StateStore{NewMemoryStateStore(), fileStore} {
+		updates, err := FindUpdates(store, incidents)
+
+		c.Assert(err, IsNil)
+		c.Assert(updates, HasLen, 20)
+		c.Assert(updates[0].IsNew, Equals, true)
+		c.Assert(updates[0].Comments, HasLen, len(incidents[0].Comments))
+
+		c.Assert(MarkSeen(store, incidents[1:]...), IsNil)
+
+		updates, err = FindUpdates(store, incidents)
+
+		c.Assert(err, IsNil)
+		c.Assert(updates, HasLen, 1)
+		c.Assert(updates[0].Incident.ID, Equals, uint(1014))
+
+		c.Assert(MarkSeen(store, incidents...), IsNil)
+
+		updates, err = FindUpdates(store, incidents)
+
+		c.Assert(err, IsNil)
+		c.Assert(updates, HasLen, 0)
+
+		changed := *incidents[2]
+		changed.Comments = append(Comments{{ID: 99999}}, changed.Comments...)
+
+		updates, err = FindUpdates(store, Incidents{&changed, nil})
+
+		c.Assert(err, IsNil)
+		c.Assert(updates, HasLen, 1)
+		c.Assert(updates[0].IsNew, Equals, false)
+		c.Assert(updates[0].Comments, HasLen, 1)
+		c.Assert(updates[0].Comments[0].ID, Equals, uint(99999))
+
+		changed = *incidents[3]
+		changed.UpdatedAt = Date{changed.UpdatedAt.Add(time.Minute)}
+
+		updates, err = FindUpdates(store, Incidents{&changed})
+
+		c.Assert(err, IsNil)
+		c.Assert(updates, HasLen, 1)
+		c.Assert(updates[0].Comments, HasLen, 0)
+	}
+
+	fileStore, err = NewFileStateStore(stateFile)
+
+	c.Assert(err, IsNil)
+	c.Assert(fileStore.data, HasLen, 20)
+
+	updates, err := FindUpdates(fileStore, incidents)
+
+	c.Assert(err, IsNil)
+	c.Assert(updates, HasLen, 0)
+
+	os.WriteFile(stateFile, []byte("{"), 0644)
+	_, err = NewFileStateStore(stateFile)
+	c.Assert(err, ErrorMatches, "Can't decode state file: .*")
+
+	_, err = NewFileStateStore(c.MkDir())
+	c.Assert(err, ErrorMatches, "Can't read state file: .*")
+
+	fileStore, err = NewFileStateStore(c.MkDir() + "/unknown/state.json")
+	c.Assert(err, IsNil)
+	c.Assert(fileStore.Set(1, &IncidentState{}), ErrorMatches, "Can't create temporary state file: .*")
+	c.Assert(fileStore.data, HasLen, 0)
+
+	c.Assert(MarkSeen(fileStore, incidents...), ErrorMatches, "Can't save state of incidents: Can't create temporary state file: .*")
+	c.Assert(fileStore.data, HasLen, 0)
+
+	basicStore := &basicStateStore{NewMemoryStateStore()}
+
+	c.Assert(MarkSeen(basicStore, incidents...), IsNil)
+	c.Assert(basicStore.store.data, HasLen, 20)
+
+	pruneDate := incidents.SortByStartDate()[10].UpdatedAt.Time
+	stateFile = c.MkDir() + "/state.json"
+	fileStore, err = NewFileStateStore(stateFile)
+	c.Assert(err, IsNil)
+	c.Assert(MarkSeen(fileStore, incidents...), IsNil)
+
+	memStore := NewMemoryStateStore()
+	c.Assert(memStore.SetMany(fileStore.data), IsNil)
+
+	n, err := memStore.Prune(time.Time{})
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 0)
+
+	n, err = fileStore.Prune(pruneDate)
+	c.Assert(err, IsNil)
+	c.Assert(n > 0 && n < 20, Equals, true)
+
+	prunedNum := n
+
+	n, err = memStore.Prune(pruneDate)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, prunedNum)
+
+	fileStore, err = NewFileStateStore(stateFile)
+	c.Assert(err, IsNil)
+	c.Assert(fileStore.data, HasLen, 20-prunedNum)
+
+	fileStore.file = c.MkDir() + "/unknown/state.json"
+	_, err = fileStore.Prune(time.Now())
+	c.Assert(err, NotNil)
+	c.Assert(fileStore.data, HasLen, 20-prunedNum)
+
+	_, err = FindUpdates(nil, incidents)
+	c.Assert(err, NotNil)
+	c.Assert(MarkSeen(nil, incidents...), NotNil)
+	c.Assert(MarkSeen(fileStore, nil), IsNil)
+
+	var nilMemStore *MemoryStateStore
+	var nilFileStore *FileStateStore
+
+	_, err = nilMemStore.Get(1)
+	c.Assert(err, NotNil)
+	c.Assert(nilMemStore.Set(1, nil), NotNil)
+	c.Assert(nilMemStore.SetMany(nil), NotNil)
+	_, err = nilMemStore.Prune(time.Now())
+	c.Assert(err, NotNil)
+	_, err = nilFileStore.Get(1)
+	c.Assert(err, NotNil)
+	c.Assert(nilFileStore.Set(1, nil), NotNil)
+	_, err = nilFileStore.Prune(time.Now())
+	c.Assert(err, NotNil)
+
+	_, err = FindUpdates(nilMemStore, incidents)
+	c.Assert(err, NotNil)
+	c.Assert(MarkSeen(nilMemStore, incidents...), NotNil)
+}
+
+func (s *YCSSuite) TestIncidentsPagination(c *C) {
+	page, err := GetIncidentsPage(IncidentsRequest{Page: 2, PageSize: 5})
+
+	c.Assert(err, IsNil)
+	c.Assert(page.Count, Equals, 81)
+	c.Assert(page.Items, HasLen, 5)
+	c.Assert(page.Items[0].ID, Equals, uint(1009))
+
+	var ids []uint
+
+	for incident, err := range IterIncidents(IncidentsRequest{PageSize: 5}) {
+		c.Assert(err, IsNil)
+		ids = append(ids, incident.ID)
+	}
+
+	c.Assert(ids, HasLen, 20)
+
+	ids = nil
+
+	for incident, err := range IterIncidents(IncidentsRequest{}) {
+		c.Assert(err, IsNil)
+		ids = append(ids, incident.ID)
+	}
+
+	c.Assert(ids, HasLen, 20)
+
+	ids = nil
+
+	for incident := range IterIncidents(IncidentsRequest{PageSize: 5}) {
+		ids = append(ids, incident.ID)
+
+		if len(ids) == 7 {
+			break
+		}
+	}
+
+	c.Assert(ids, HasLen, 7)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for incident, err := range IterIncidentsContext(ctx, IncidentsRequest{}) {
+		c.Assert(incident, IsNil)
+		c.Assert(errors.Is(err, context.Canceled), Equals, true)
+	}
+
+	_, err = GetIncidentsPageContext(ctx, IncidentsRequest{})
+	c.Assert(err, NotNil)
+
+	var client *Client
+
+	_, err = client.GetIncidentsPage(IncidentsRequest{})
+	c.Assert(err, Equals, ErrNilClient)
+}
+
+func (s *YCSSuite) TestContext(c *C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := GetServicesContext(ctx, LANG_RU)
+	c.Assert(err, NotNil)
+	c.Assert(errors.Is(err, context.Canceled), Equals, true)
+
+	_, err = GetIncidentsContext(ctx, IncidentsRequest{})
+	c.Assert(err, NotNil)
+	c.Assert(errors.Is(err, context.Canceled), Equals, true)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = GetIncidentContext(ctx, 1, LANG_RU)
+	c.Assert(err, NotNil)
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+
+	_, err = GetIncidentContext(context.Background(), 972, LANG_RU)
+	c.Assert(err, IsNil)
+}
+
+func (s *YCSSuite) TestLimiter(c *C) {
+	client := NewClient()
+	client.SetURL("http://127.0.0.1:" + TEST_PORT)
+	client.SetRetryPolicy(RetryPolicy{})
+	client.SetLimit(0.1)
+
+	_, err := client.GetIncident(972, LANG_RU)
+	c.Assert(err, IsNil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	next := client.limiter.next
+	start := time.Now()
+
+	_, err = client.GetIncidentContext(ctx, 972, LANG_RU)
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+	c.Assert(time.Since(start) < time.Second, Equals, true)
+	c.Assert(client.limiter.next, Equals, next)
+
+	l := newLimiter(1000)
+	wg := &sync.WaitGroup{}
+	start = time.Now()
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			l.Wait(context.Background())
+		}()
+	}
+
+	wg.Wait()
+
+	c.Assert(time.Since(start) >= 9*time.Millisecond, Equals, true)
+
+	var nilLimiter *limiter
+
+	c.Assert(newLimiter(0), IsNil)
+	c.Assert(nilLimiter.Wait(context.Background()), IsNil)
+}
+
+func (s *YCSSuite) TestRetries(c *C) {
+	var attempts []Attempt
+
+	client := NewClient()
+	client.SetURL("http://127.0.0.1:" + TEST_PORT)
+
+	c.Assert(client.retry.MaxAttempts, Equals, DefaultRetryPolicy.MaxAttempts)
+	c.Assert(client.SetRetryPolicy(RetryPolicy{MaxAttempts: -1}), NotNil)
+	c.Assert(client.SetRetryPolicy(RetryPolicy{MinDelay: -1}), NotNil)
+	c.Assert(client.SetRetryPolicy(RetryPolicy{MaxDelay: -1}), NotNil)
+	c.Assert(client.SetRetryPolicy(RetryPolicy{MinDelay: 2, MaxDelay: 1}), NotNil)
+	c.Assert(client.SetRetryPolicy(RetryPolicy{Jitter: 2}), NotNil)
+
+	err := client.SetRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+		MinDelay:    10 * time.Millisecond,
+		MaxDelay:    50 * time.Millisecond,
+		Jitter:      0.1,
+		OnAttempt:   func(a Attempt) { attempts = append(attempts, a) },
+	})
+
+	c.Assert(err, IsNil)
+
+	flakyCounter.Store(0)
+
+	incident, err := client.GetIncident(3, LANG_EN)
+
+	c.Assert(err, IsNil)
+	c.Assert(incident, NotNil)
+	c.Assert(attempts, HasLen, 3)
+	c.Assert(attempts[0].Num, Equals, 1)
+	c.Assert(attempts[0].StatusCode, Equals, 503)
+	c.Assert(attempts[0].Delay > 0, Equals, true)
+	c.Assert(attempts[1].StatusCode, Equals, 429)
+	c.Assert(attempts[1].Delay > 0, Equals, true)
+	c.Assert(attempts[2].Err, IsNil)
+
+	attempts = nil
+
+	_, err = client.GetIncident(2, LANG_EN)
+
+	c.Assert(errors.Is(err, ErrNotFound), Equals, true)
+	c.Assert(attempts, HasLen, 1)
+
+	attempts = nil
+	client.SetUserAgent("http-error", "1")
+
+	_, err = client.GetServices(LANG_EN)
+
+	c.Assert(errors.Is(err, ErrServerError), Equals, true)
+	c.Assert(attempts, HasLen, 3)
+	c.Assert(attempts[2].Delay, Equals, time.Duration(0))
+
+	var nilClient *Client
+	c.Assert(nilClient.SetRetryPolicy(DefaultRetryPolicy), Equals, ErrNilClient)
+	c.Assert(SetRetryPolicy(RetryPolicy{}), IsNil)
+}
+
+func (s *YCSSuite) TestRetryDelay(c *C) {
+	p := RetryPolicy{MinDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	c.Assert(p.attempts(), Equals, 1)
+	c.Assert(p.Delay(1, 0), Equals, time.Second)
+	c.Assert(p.Delay(2, 0), Equals, 2*time.Second)
+	c.Assert(p.Delay(3, 0), Equals, 4*time.Second)
+	c.Assert(p.Delay(10, 0), Equals, 5*time.Second)
+	c.Assert(p.Delay(1, 3*time.Second), Equals, 3*time.Second)
+	c.Assert(p.Delay(1, time.Hour), Equals, 5*time.Second)
+
+	p.IgnoreRetryAfter = true
+	c.Assert(p.Delay(1, 7*time.Second), Equals, time.Second)
+
+	p.Jitter = 0.5
+	d := p.Delay(1, 0)
+	c.Assert(d >= 500*time.Millisecond && d <= 1500*time.Millisecond, Equals, true)
+
+	for range 20 {
+		d = p.Delay(10, 0)
+		c.Assert(d >= 2500*time.Millisecond && d <= 5*time.Second, Equals, true)
+	}
+
+	p = RetryPolicy{MinDelay: time.Second}
+	c.Assert(p.Delay(1, 3*time.Second), Equals, 3*time.Second)
+	c.Assert(p.Delay(1, 48*time.Hour), Equals, maxRetryDelay)
+	c.Assert(p.Delay(1000, 0), Equals, maxRetryDelay)
+
+	p = RetryPolicy{MinDelay: time.Second, MaxDelay: time.Duration(math.MaxInt64), Jitter: 1}
+	c.Assert(p.Delay(1000, 0) > 0, Equals, true)
+
+	p.Jitter = 0
+	c.Assert(p.Delay(1000, 0), Equals, time.Duration(math.MaxInt64))
+
+	c.Assert(ParseRetryAfter(""), Equals, time.Duration(0))
+	c.Assert(ParseRetryAfter("ABCD"), Equals, time.Duration(0))
+	c.Assert(ParseRetryAfter("3"), Equals, 3*time.Second)
+	c.Assert(ParseRetryAfter("Wed, 21 Oct 2015 07:28:00 GMT"), Equals, time.Duration(0))
+
+	d = ParseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	c.Assert(d > 58*time.Minute && d <= time.Hour, Equals, true)
 }
 
 func (s *YCSSuite) TestDateParsing(c *C) {
@@ -166,19 +1482,183 @@ func (s *YCSSuite) TestDateParsing(c *C) {
 
 	err = d.UnmarshalJSON([]byte(`ABCD`))
 	c.Assert(err, NotNil)
+
+	data, err := Date{}.MarshalJSON()
+
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `null`)
+
+	d.Time = time.Date(2025, 1, 22, 21, 52, 41, 123000000, time.UTC)
+	data, err = d.MarshalJSON()
+
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `"2025-01-22T21:52:41.123Z"`)
+}
+
+func (s *YCSSuite) TestJSONRoundTrip(c *C) {
+	services, err := GetServices(LANG_RU)
+	c.Assert(err, IsNil)
+
+	data, err := json.Marshal(services)
+	c.Assert(err, IsNil)
+
+	var decodedServices Services
+	c.Assert(json.Unmarshal(data, &decodedServices), IsNil)
+	c.Assert(decodedServices, DeepEquals, services)
+
+	incidents, err := GetIncidents(IncidentsRequest{})
+	c.Assert(err, IsNil)
+
+	data, err = json.Marshal(incidents)
+	c.Assert(err, IsNil)
+
+	var decodedIncidents Incidents
+	c.Assert(json.Unmarshal(data, &decodedIncidents), IsNil)
+	c.Assert(decodedIncidents, DeepEquals, incidents)
+
+	incident, err := GetIncident(972, LANG_EN)
+	c.Assert(err, IsNil)
+
+	data, err = json.Marshal(incident)
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(data), `"startDate":"2024-10-16T11:40:00.000Z"`), Equals, true)
+
+	var decodedIncident *Incident
+	c.Assert(json.Unmarshal(data, &decodedIncident), IsNil)
+	c.Assert(decodedIncident, DeepEquals, incident)
+
+	data, err = json.Marshal(&Comment{ID: 1})
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(data), `"createdAt":null`), Equals, true)
 }
 
 func (s *YCSSuite) TestHtml2Markdown(c *C) {
+	c.Assert(htmlToMarkdown(""), Equals, "")
 	c.Assert(htmlToMarkdown("<i>italic</i>"), Equals, "*italic*")
+	c.Assert(htmlToMarkdown("<em>italic</em>"), Equals, "*italic*")
 	c.Assert(htmlToMarkdown("<b>bold</b>"), Equals, "**bold**")
 	c.Assert(htmlToMarkdown("<strong>bold</strong>"), Equals, "**bold**")
-	c.Assert(htmlToMarkdown("<pre>code</pre>"), Equals, "`code`")
-	c.Assert(htmlToMarkdown("<code>code-block</code>"), Equals, "```code-block```")
-	c.Assert(htmlToMarkdown("<br/>"), Equals, "\n")
-	c.Assert(htmlToMarkdown("<ul><li>Test</li></ul>"), Equals, "• Test")
+	c.Assert(htmlToMarkdown("Text<strong> bold </strong>text"), Equals, "Text **bold** text")
+	c.Assert(htmlToMarkdown("<code>code</code>"), Equals, "`code`")
+	c.Assert(htmlToMarkdown("<code>a `b` c</code>"), Equals, "``a `b` c``")
+	c.Assert(htmlToMarkdown("<pre>code</pre>"), Equals, "```\ncode\n```")
+	c.Assert(htmlToMarkdown("<pre><code class=\"language-go\">a := 1\n\nb := 2\n</code></pre>"), Equals, "```go\na := 1\n\nb := 2\n```")
+	c.Assert(htmlToMarkdown("<pre>```\n*x*</pre>"), Equals, "````\n```\n*x*\n````")
+	c.Assert(htmlToMarkdown("<br/>"), Equals, "")
+	c.Assert(htmlToMarkdown("Line 1<br/>Line 2<br>"), Equals, "Line 1  \nLine 2")
+	c.Assert(htmlToMarkdown("<ul><li>Test</li></ul>"), Equals, "- Test")
 	c.Assert(htmlToMarkdown("<ol><li>Test</li></ol>"), Equals, "1. Test")
+	c.Assert(htmlToMarkdown("<ol start=\"9\"><li>A</li><li>B</li></ol>"), Equals, "9. A\n10. B")
+	c.Assert(htmlToMarkdown("<ul><li></li></ul>"), Equals, "-")
+	c.Assert(htmlToMarkdown(
+		"<ul>\n<li><p>Item 1</p>\n<ol><li>Sub 1<ul><li>Deep</li></ul></li><li>Sub 2</li></ol></li>\n<li>Item 2<br>next line</li></ul>",
+	), Equals, "- Item 1\n  1. Sub 1\n     - Deep\n  2. Sub 2\n- Item 2  \n  next line")
+	c.Assert(htmlToMarkdown("<ul><li><p>Para 1</p><p>Para 2</p></li></ul>"), Equals, "- Para 1\n\n  Para 2")
+	c.Assert(htmlToMarkdown("<h1>Title</h1><h3>Sub <em>title</em></h3><p>Text</p>"), Equals, "# Title\n\n### Sub *title*\n\nText")
+	c.Assert(htmlToMarkdown("<p>A</p><hr><p>B</p>"), Equals, "A\n\n---\n\nB")
+	c.Assert(htmlToMarkdown("<blockquote><p>Quote</p><p>Next</p></blockquote>"), Equals, "> Quote\n>\n> Next")
+	c.Assert(htmlToMarkdown(
+		"<table><thead><tr><th>Zone</th><th>Status</th></tr></thead><tbody><tr><td>ru-central1-a</td><td><b>Down</b> | partial</td></tr><tr><td>kz1-a</td></tr></tbody></table>",
+	), Equals, "| Zone | Status |\n| --- | --- |\n| ru-central1-a | **Down** \\| partial |\n| kz1-a |  |")
+	c.Assert(htmlToMarkdown("<table></table>"), Equals, "")
 	c.Assert(htmlToMarkdown(`<a href="https://domain.com" title="title">Link</a>`), Equals, "[Link](https://domain.com)")
-	c.Assert(htmlToMarkdown(`<img src="https://domain.com/image.png" />`), Equals, "![IMG](https://domain.com/image.png)")
+	c.Assert(htmlToMarkdown(`<a href="https://domain.com/a (b)"><b>Link</b></a>`), Equals, "[**Link**](https://domain.com/a%20%28b%29)")
+	c.Assert(htmlToMarkdown(`<a href="https://domain.com"></a>`), Equals, "[https://domain.com](https://domain.com)")
+	c.Assert(htmlToMarkdown(`<a>Text</a>`), Equals, "Text")
+	c.Assert(htmlToMarkdown(`<img src="https://domain.com/image.png" />`), Equals, "![](https://domain.com/image.png)")
+	c.Assert(htmlToMarkdown(`<img src="https://domain.com/image.png" alt="Chart [1]" />`), Equals, "![Chart \\[1\\]](https://domain.com/image.png)")
+	c.Assert(htmlToMarkdown(`<img alt="No source" />`), Equals, "")
+	c.Assert(htmlToMarkdown("<p>2 * 3 = 6, snake_case &amp; &lt;tag&gt;</p>"), Equals, "2 \\* 3 = 6, snake\\_case & \\<tag>")
+	c.Assert(htmlToMarkdown("<p>1. Not a list<br># Not a heading<br>- Not an item<br>===</p>"), Equals, "1\\. Not a list  \n\\# Not a heading  \n\\- Not an item  \n\\===")
+	c.Assert(htmlToMarkdown("<div><span>Text</span><script>alert(1)</script><p>Para</p></div>"), Equals, "Text\n\nPara")
+	c.Assert(htmlToMarkdown("Plain   text\nwith spaces"), Equals, "Plain text with spaces")
+}
+
+func (s *YCSSuite) TestHtml2Text(c *C) {
+	src := `<h2>Title <b>x</b></h2><p>Some <b>bold text</b> and <a href="https://a.com/x">a link</a>, ` +
+		`<a href="mailto:a@b.c">a@b.c</a>, <a href="https://a.com/x">again</a></p>` +
+		`<ul><li>One<ol><li>Sub one that is rather long and must wrap nicely</li></ol></li><li>Two</li></ul>` +
+		`<blockquote>Quoted text here for wrapping test</blockquote><hr>` +
+		`<table><tr><th>Zone</th><th>State</th></tr><tr><td>ru-central1-a</td><td>down</td></tr></table>` +
+		"<pre>code\n  line</pre><img src=\"https://x/y.png\" alt=\"Chart\">"
+
+	c.Assert(htmlToText(src, 30, false), Equals, `Title x
+
+Some bold text and a link[1],
+a@b.c, again[1]
+
+- One
+  1. Sub one that is rather
+     long and must wrap nicely
+- Two
+
+> Quoted text here for
+> wrapping test
+
+------------------------------
+
+Zone           State
+-------------  -----
+ru-central1-a  down
+
+    code
+      line
+
+[image: Chart][2]
+
+[1] https://a.com/x
+[2] https://x/y.png`)
+
+	ansi := htmlToText(src, 30, true)
+
+	c.Assert(ansi, Matches, "(?s)\x1b\\[36m\x1b\\[1mTitle\x1b\\[22m\x1b\\[39m .*")
+	c.Assert(strings.Contains(ansi, "Some \x1b[1mbold\x1b[22m \x1b[1mtext\x1b[22m and \x1b[4ma\x1b[24m \x1b[4mlink\x1b[24m\x1b[90m[1]\x1b[39m,\n"), Equals, true)
+	c.Assert(strings.Contains(ansi, "\n• One\n  1. Sub one that is rather\n"), Equals, true)
+	c.Assert(strings.Contains(ansi, "\n\x1b[90m│\x1b[39m Quoted text here for\n"), Equals, true)
+	c.Assert(strings.Contains(ansi, "\n    \x1b[33m  line\x1b[39m\n"), Equals, true)
+	c.Assert(strings.Contains(ansi, "\n"+strings.Repeat("─", 30)+"\n"), Equals, true)
+	c.Assert(strings.Contains(ansi, "\n\x1b[1mZone\x1b[22m           \x1b[1mState\x1b[22m\n─────────────  ─────\n"), Equals, true)
+
+	c.Assert(htmlToText("", 80, false), Equals, "")
+	c.Assert(htmlToText("<p>Line 1<br>Line 2</p><p><i>Text</i></p>", 0, false), Equals, "Line 1\nLine 2\n\nText")
+	c.Assert(htmlToText("<i>Text</i> <code>a  b</code>", 0, true), Equals, "\x1b[3mText\x1b[23m \x1b[33ma  b\x1b[39m")
+	c.Assert(htmlToText("<p>Run <code>go  test ./...</code> now</p>", 12, false), Equals, "Run\ngo  test ./...\nnow")
+	c.Assert(htmlToText("<table><tr><td><code>a  b</code></td><td>c</td></tr></table>", 0, false), Equals, "a  b  c\n----  -")
+	c.Assert(htmlToText(`<a href="https://a.com">https://a.com</a> <a>Text</a> <a href="https://b.com"></a>`, 0, false), Equals, "https://a.com Text https://b.com")
+	c.Assert(htmlToText(`<img src="https://a.com/i.png"><img alt="Empty">`, 0, false), Equals, "[image][1]\n\n[1] https://a.com/i.png")
+	c.Assert(htmlToText("<ol start=\"9\"><li>A</li><li></li></ol>", 0, false), Equals, "9. A\n10.")
+	c.Assert(htmlToText("<hr>", 0, false), Equals, strings.Repeat("-", 40))
+	c.Assert(htmlToText("<p>Verylongwordwhichdoesnotfit short</p>", 10, false), Equals, "Verylongwordwhichdoesnotfit\nshort")
+	c.Assert(htmlToText("<table></table><script>alert(1)</script>", 0, false), Equals, "")
+
+	incident, err := GetIncident(972, LANG_EN)
+	c.Assert(err, IsNil)
+
+	text := incident.ReportText(60)
+
+	c.Assert(text, Matches, "(?s)Short description\\.\n\nOn 16th of October, .*")
+	c.Assert(strings.Contains(text, "\n- Compute Cloud: A temporary increase in task and operation\n  processing times in ru-central1-a.\n"), Equals, true)
+	c.Assert(strings.Contains(text, "\n[image: Volume of routing information in ru-central1-a][1]\n"), Equals, true)
+	c.Assert(strings.Contains(text, "\n[1] https://storage.yandexcloud.net/"), Equals, true)
+
+	for _, line := range strings.Split(text, "\n") {
+		if !strings.HasPrefix(line, "[1] ") {
+			c.Assert(utf8.RuneCountInString(line) <= 60, Equals, true, Commentf("Line is too long: %q", line))
+		}
+	}
+
+	c.Assert(ansiRegex.ReplaceAllString(incident.ReportANSI(0), ""), Not(Equals), "")
+	c.Assert(strings.Contains(incident.ReportText(0), "\x1b["), Equals, false)
+	c.Assert(incident.Comments.Latest().Text(40), Not(Equals), "")
+	c.Assert(incident.Comments.Latest().ANSI(40), Not(Equals), "")
+
+	incident = nil
+	var comment *Comment
+
+	c.Assert(incident.ReportText(80), Equals, "")
+	c.Assert(incident.ReportANSI(80), Equals, "")
+	c.Assert(comment.Text(80), Equals, "")
+	c.Assert(comment.ANSI(80), Equals, "")
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -198,8 +1678,26 @@ func handlerIncidents(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rw.WriteHeader(200)
 	data, _ := os.ReadFile("testdata/incidents.json")
+
+	if r.URL.Query().Has("limit") {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		resp := &struct {
+			Items []json.RawMessage `json:"items"`
+			Count int               `json:"count"`
+		}{}
+
+		json.Unmarshal(data, resp)
+
+		from := min((page-1)*limit, len(resp.Items))
+		resp.Items = resp.Items[from:min(from+limit, len(resp.Items))]
+
+		data, _ = json.Marshal(resp)
+	}
+
+	rw.WriteHeader(200)
 	rw.Write(data)
 }
 
@@ -213,9 +1711,37 @@ func handlerIncident(rw http.ResponseWriter, r *http.Request) {
 	rw.Write(data)
 }
 
+func handlerSlow(rw http.ResponseWriter, r *http.Request) {
+	select {
+	case <-r.Context().Done():
+	case <-time.After(time.Second):
+	}
+
+	rw.WriteHeader(200)
+	rw.Write([]byte(`{}`))
+}
+
+func handlerFlaky(rw http.ResponseWriter, r *http.Request) {
+	switch flakyCounter.Add(1) {
+	case 1:
+		rw.WriteHeader(503)
+	case 2:
+		rw.Header().Set("Retry-After", "0")
+		rw.WriteHeader(429)
+	default:
+		handlerIncident(rw, r)
+	}
+}
+
 func writeErrorResponse(rw http.ResponseWriter, r *http.Request) bool {
 	if strings.Contains(r.Header.Get("User-Agent"), "http-error") {
 		rw.WriteHeader(503)
+		rw.Write([]byte("Service Unavailable"))
+		return true
+	}
+
+	if strings.Contains(r.Header.Get("User-Agent"), "rate-error") {
+		rw.WriteHeader(429)
 		return true
 	}
 
@@ -227,3 +1753,13 @@ func writeErrorResponse(rw http.ResponseWriter, r *http.Request) bool {
 
 	return false
 }
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *basicStateStore) Get(id uint) (*IncidentState, error) {
+	return s.store.Get(id)
+}
+
+func (s *basicStateStore) Set(id uint, state *IncidentState) error {
+	return s.store.Set(id, state)
+}